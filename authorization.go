@@ -3,16 +3,22 @@ package main
 import (
 	"flag"
 	"fmt"
+	"os"
 
 	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/health"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/interceptor"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/replay"
 	"github.com/xh-polaris/meowchat-authorization-rpc/internal/server"
 	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
 	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
 
 	"github.com/zeromicro/go-zero/core/conf"
+	"github.com/zeromicro/go-zero/core/proc"
 	"github.com/zeromicro/go-zero/core/service"
 	"github.com/zeromicro/go-zero/zrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -21,12 +27,25 @@ var configFile = flag.String("f", "etc/authorization.yaml", "the config file")
 func main() {
 	flag.Parse()
 
+	// 离线重放录制的请求: authorization -f etc/authorization.yaml replay -requests r.jsonl -fixture world.yaml
+	if flag.Arg(0) == "replay" {
+		if err := replay.Run(*configFile, flag.Args()[1:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	var c config.Config
 	conf.MustLoad(*configFile, &c, conf.UseEnv())
 	ctx := svc.NewServiceContext(c)
+	// go-zero 自带的健康检查始终报告 SERVING，改为按上游服务状态报告
+	c.Health = false
+	checker := health.NewChecker(c.HealthCheck, ctx.Upstreams)
 
 	s := zrpc.MustNewServer(c.RpcServerConf, func(grpcServer *grpc.Server) {
 		pb.RegisterAuthorizationServer(grpcServer, server.NewAuthorizationServer(ctx))
+		grpc_health_v1.RegisterHealthServer(grpcServer, checker)
 
 		if c.Mode == service.DevMode || c.Mode == service.TestMode {
 			reflection.Register(grpcServer)
@@ -34,6 +53,23 @@ func main() {
 	})
 	defer s.Stop()
 
+	if c.CallerAuth.TLS != nil {
+		s.AddOptions(grpc.Creds(interceptor.MustNewServerCreds(*c.CallerAuth.TLS)))
+	}
+	if c.CallerAuth.Enabled() {
+		auth := interceptor.NewCallerAuth(c.CallerAuth)
+		s.AddUnaryInterceptors(auth.UnaryInterceptor)
+		s.AddStreamInterceptors(auth.StreamInterceptor)
+	}
+	if c.UserToken.Mode == config.UserTokenOptional || c.UserToken.Mode == config.UserTokenRequired {
+		s.AddUnaryInterceptors(interceptor.MustNewUserToken(c.UserToken).UnaryInterceptor)
+	}
+	// 在认证之后限流，以便按调用方和令牌中的用户计数
+	s.AddUnaryInterceptors(interceptor.MustNewRateLimit(c.RateLimit).UnaryInterceptor)
+
+	checker.Start()
+	proc.AddWrapUpListener(checker.Stop)
+
 	fmt.Printf("Starting rpc server at %s...\n", c.ListenOn)
 	s.Start()
 }
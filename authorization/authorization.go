@@ -13,11 +13,57 @@ import (
 )
 
 type (
-	AllowReq  = pb.AllowReq
-	AllowResp = pb.AllowResp
+	AddMemberReq               = pb.AddMemberReq
+	AddMemberResp              = pb.AddMemberResp
+	AllowReq                   = pb.AllowReq
+	AllowResp                  = pb.AllowResp
+	BlockReq                   = pb.BlockReq
+	BlockResp                  = pb.BlockResp
+	CloseReportReq             = pb.CloseReportReq
+	CloseReportResp            = pb.CloseReportResp
+	FollowReq                  = pb.FollowReq
+	FollowResp                 = pb.FollowResp
+	GrantReq                   = pb.GrantReq
+	GrantResp                  = pb.GrantResp
+	ListBlockReq               = pb.ListBlockReq
+	ListBlockResp              = pb.ListBlockResp
+	LockReq                    = pb.LockReq
+	LockResp                   = pb.LockResp
+	RemoveMemberReq            = pb.RemoveMemberReq
+	RemoveMemberResp           = pb.RemoveMemberResp
+	ReportReq                  = pb.ReportReq
+	ReportResp                 = pb.ReportResp
+	RevokeReq                  = pb.RevokeReq
+	RevokeResp                 = pb.RevokeResp
+	Role                       = pb.Role
+	SetCommunityVisibilityReq  = pb.SetCommunityVisibilityReq
+	SetCommunityVisibilityResp = pb.SetCommunityVisibilityResp
+	SetProfileVisibilityReq    = pb.SetProfileVisibilityReq
+	SetProfileVisibilityResp   = pb.SetProfileVisibilityResp
+	UnblockReq                 = pb.UnblockReq
+	UnblockResp                = pb.UnblockResp
+	UnfollowReq                = pb.UnfollowReq
+	UnfollowResp               = pb.UnfollowResp
+	UnlockReq                  = pb.UnlockReq
+	UnlockResp                 = pb.UnlockResp
 
 	Authorization interface {
 		Allow(ctx context.Context, in *AllowReq, opts ...grpc.CallOption) (*AllowResp, error)
+		Block(ctx context.Context, in *BlockReq, opts ...grpc.CallOption) (*BlockResp, error)
+		Unblock(ctx context.Context, in *UnblockReq, opts ...grpc.CallOption) (*UnblockResp, error)
+		ListBlock(ctx context.Context, in *ListBlockReq, opts ...grpc.CallOption) (*ListBlockResp, error)
+		Lock(ctx context.Context, in *LockReq, opts ...grpc.CallOption) (*LockResp, error)
+		Unlock(ctx context.Context, in *UnlockReq, opts ...grpc.CallOption) (*UnlockResp, error)
+		SetProfileVisibility(ctx context.Context, in *SetProfileVisibilityReq, opts ...grpc.CallOption) (*SetProfileVisibilityResp, error)
+		SetCommunityVisibility(ctx context.Context, in *SetCommunityVisibilityReq, opts ...grpc.CallOption) (*SetCommunityVisibilityResp, error)
+		AddMember(ctx context.Context, in *AddMemberReq, opts ...grpc.CallOption) (*AddMemberResp, error)
+		RemoveMember(ctx context.Context, in *RemoveMemberReq, opts ...grpc.CallOption) (*RemoveMemberResp, error)
+		Grant(ctx context.Context, in *GrantReq, opts ...grpc.CallOption) (*GrantResp, error)
+		Revoke(ctx context.Context, in *RevokeReq, opts ...grpc.CallOption) (*RevokeResp, error)
+		Report(ctx context.Context, in *ReportReq, opts ...grpc.CallOption) (*ReportResp, error)
+		CloseReport(ctx context.Context, in *CloseReportReq, opts ...grpc.CallOption) (*CloseReportResp, error)
+		Follow(ctx context.Context, in *FollowReq, opts ...grpc.CallOption) (*FollowResp, error)
+		Unfollow(ctx context.Context, in *UnfollowReq, opts ...grpc.CallOption) (*UnfollowResp, error)
 	}
 
 	defaultAuthorization struct {
@@ -35,3 +81,78 @@ func (m *defaultAuthorization) Allow(ctx context.Context, in *AllowReq, opts ...
 	client := pb.NewAuthorizationClient(m.cli.Conn())
 	return client.Allow(ctx, in, opts...)
 }
+
+func (m *defaultAuthorization) Block(ctx context.Context, in *BlockReq, opts ...grpc.CallOption) (*BlockResp, error) {
+	client := pb.NewAuthorizationClient(m.cli.Conn())
+	return client.Block(ctx, in, opts...)
+}
+
+func (m *defaultAuthorization) Unblock(ctx context.Context, in *UnblockReq, opts ...grpc.CallOption) (*UnblockResp, error) {
+	client := pb.NewAuthorizationClient(m.cli.Conn())
+	return client.Unblock(ctx, in, opts...)
+}
+
+func (m *defaultAuthorization) ListBlock(ctx context.Context, in *ListBlockReq, opts ...grpc.CallOption) (*ListBlockResp, error) {
+	client := pb.NewAuthorizationClient(m.cli.Conn())
+	return client.ListBlock(ctx, in, opts...)
+}
+
+func (m *defaultAuthorization) Lock(ctx context.Context, in *LockReq, opts ...grpc.CallOption) (*LockResp, error) {
+	client := pb.NewAuthorizationClient(m.cli.Conn())
+	return client.Lock(ctx, in, opts...)
+}
+
+func (m *defaultAuthorization) Unlock(ctx context.Context, in *UnlockReq, opts ...grpc.CallOption) (*UnlockResp, error) {
+	client := pb.NewAuthorizationClient(m.cli.Conn())
+	return client.Unlock(ctx, in, opts...)
+}
+
+func (m *defaultAuthorization) SetProfileVisibility(ctx context.Context, in *SetProfileVisibilityReq, opts ...grpc.CallOption) (*SetProfileVisibilityResp, error) {
+	client := pb.NewAuthorizationClient(m.cli.Conn())
+	return client.SetProfileVisibility(ctx, in, opts...)
+}
+
+func (m *defaultAuthorization) SetCommunityVisibility(ctx context.Context, in *SetCommunityVisibilityReq, opts ...grpc.CallOption) (*SetCommunityVisibilityResp, error) {
+	client := pb.NewAuthorizationClient(m.cli.Conn())
+	return client.SetCommunityVisibility(ctx, in, opts...)
+}
+
+func (m *defaultAuthorization) AddMember(ctx context.Context, in *AddMemberReq, opts ...grpc.CallOption) (*AddMemberResp, error) {
+	client := pb.NewAuthorizationClient(m.cli.Conn())
+	return client.AddMember(ctx, in, opts...)
+}
+
+func (m *defaultAuthorization) RemoveMember(ctx context.Context, in *RemoveMemberReq, opts ...grpc.CallOption) (*RemoveMemberResp, error) {
+	client := pb.NewAuthorizationClient(m.cli.Conn())
+	return client.RemoveMember(ctx, in, opts...)
+}
+
+func (m *defaultAuthorization) Grant(ctx context.Context, in *GrantReq, opts ...grpc.CallOption) (*GrantResp, error) {
+	client := pb.NewAuthorizationClient(m.cli.Conn())
+	return client.Grant(ctx, in, opts...)
+}
+
+func (m *defaultAuthorization) Revoke(ctx context.Context, in *RevokeReq, opts ...grpc.CallOption) (*RevokeResp, error) {
+	client := pb.NewAuthorizationClient(m.cli.Conn())
+	return client.Revoke(ctx, in, opts...)
+}
+
+func (m *defaultAuthorization) Report(ctx context.Context, in *ReportReq, opts ...grpc.CallOption) (*ReportResp, error) {
+	client := pb.NewAuthorizationClient(m.cli.Conn())
+	return client.Report(ctx, in, opts...)
+}
+
+func (m *defaultAuthorization) CloseReport(ctx context.Context, in *CloseReportReq, opts ...grpc.CallOption) (*CloseReportResp, error) {
+	client := pb.NewAuthorizationClient(m.cli.Conn())
+	return client.CloseReport(ctx, in, opts...)
+}
+
+func (m *defaultAuthorization) Follow(ctx context.Context, in *FollowReq, opts ...grpc.CallOption) (*FollowResp, error) {
+	client := pb.NewAuthorizationClient(m.cli.Conn())
+	return client.Follow(ctx, in, opts...)
+}
+
+func (m *defaultAuthorization) Unfollow(ctx context.Context, in *UnfollowReq, opts ...grpc.CallOption) (*UnfollowResp, error) {
+	client := pb.NewAuthorizationClient(m.cli.Conn())
+	return client.Unfollow(ctx, in, opts...)
+}
@@ -0,0 +1,128 @@
+package authorization
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/zeromicro/go-zero/core/collection"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/syncx"
+	"github.com/zeromicro/go-zero/core/trace"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultCacheTTL   = 5 * time.Second
+	defaultCacheLimit = 10000
+)
+
+type (
+	// CacheOption 自定义客户端缓存
+	CacheOption func(*cacheOptions)
+
+	cacheOptions struct {
+		ttl   time.Duration
+		limit int
+	}
+
+	cachedAuthorization struct {
+		Authorization
+		cache  *collection.Cache
+		flight syncx.SingleFlight
+	}
+)
+
+// WithCacheTTL 设置读判定的缓存时长，默认 5s
+func WithCacheTTL(ttl time.Duration) CacheOption {
+	return func(o *cacheOptions) {
+		o.ttl = ttl
+	}
+}
+
+// WithCacheLimit 设置最多缓存的判定数，超出时淘汰最久未使用的，默认 10000
+func WithCacheLimit(limit int) CacheOption {
+	return func(o *cacheOptions) {
+		o.limit = limit
+	}
+}
+
+// NewCachedAuthorization 在客户端缓存读判定，并合并并发的相同请求
+//  写判定和降级的判定不缓存，权限变更最多延迟 ttl 生效
+func NewCachedAuthorization(auth Authorization, opts ...CacheOption) Authorization {
+	o := cacheOptions{
+		ttl:   defaultCacheTTL,
+		limit: defaultCacheLimit,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	cache, err := collection.NewCache(o.ttl, collection.WithLimit(o.limit),
+		collection.WithName("authorization-client"))
+	logx.Must(err)
+	return &cachedAuthorization{
+		Authorization: auth,
+		cache:         cache,
+		flight:        syncx.NewSingleFlight(),
+	}
+}
+
+func (c *cachedAuthorization) Allow(ctx context.Context, in *AllowReq, opts ...grpc.CallOption) (*AllowResp, error) {
+	key := cacheKey(ctx, in)
+	read := in.Action == constant.ActionRead
+	ctx, span := otel.Tracer(trace.TraceName).Start(ctx, "authorization/cache", oteltrace.WithAttributes(
+		attribute.String(constant.TraceObject, in.Object),
+		attribute.String(constant.TraceAction, in.Action),
+	))
+	defer span.End()
+	if read {
+		if v, ok := c.cache.Get(key); ok {
+			span.SetAttributes(attribute.String(constant.TraceCache, constant.CacheHit))
+			return proto.Clone(v.(*AllowResp)).(*AllowResp), nil
+		}
+		span.SetAttributes(attribute.String(constant.TraceCache, constant.CacheMiss))
+	}
+
+	v, err := c.flight.Do(key, func() (interface{}, error) {
+		return c.Authorization.Allow(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 降级的判定不缓存
+	resp := v.(*AllowResp)
+	if read && !resp.Degraded {
+		c.cache.Set(key, resp)
+	}
+	// 合并的请求共享同一个响应，各自返回一份拷贝
+	return proto.Clone(resp).(*AllowResp), nil
+}
+
+// 携带终端用户 JWT 的请求由服务端按 token 确定用户，key 包含 token 的摘要，不同用户的判定不会共用
+func cacheKey(ctx context.Context, in *AllowReq) string {
+	return strings.Join([]string{in.UserId, in.Object, in.ObjectId, in.Action, in.ParentObject, in.ParentId,
+		in.GetRole().GetType(), in.GetRole().GetCommunityId(), userTokenDigest(ctx)}, "\x00")
+}
+
+func userTokenDigest(ctx context.Context) string {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		return ""
+	}
+	tokens := md.Get(constant.MetadataUserToken)
+	if len(tokens) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(strings.Join(tokens, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
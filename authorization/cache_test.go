@@ -0,0 +1,127 @@
+package authorization
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type countingAuthorization struct {
+	Authorization
+	calls int32
+	delay time.Duration
+}
+
+func (a *countingAuthorization) Allow(context.Context, *AllowReq, ...grpc.CallOption) (*AllowResp, error) {
+	atomic.AddInt32(&a.calls, 1)
+	time.Sleep(a.delay)
+	return &AllowResp{Allow: true}, nil
+}
+
+// 只允许携带 alice-token 的请求，模拟服务端按 token 确定用户
+type tokenAuthorization struct {
+	Authorization
+	calls int32
+}
+
+func (a *tokenAuthorization) Allow(ctx context.Context, _ *AllowReq, _ ...grpc.CallOption) (*AllowResp, error) {
+	atomic.AddInt32(&a.calls, 1)
+	md, _ := metadata.FromOutgoingContext(ctx)
+	tokens := md.Get(MetadataUserToken)
+	return &AllowResp{Allow: len(tokens) == 1 && tokens[0] == "alice-token"}, nil
+}
+
+func TestCachedAuthorization(t *testing.T) {
+	read := &AllowReq{UserId: "UserId", Object: ObjectPost, ObjectId: "PostId", Action: ActionRead}
+	write := &AllowReq{UserId: "UserId", Object: ObjectPost, ObjectId: "PostId", Action: ActionWrite}
+
+	Convey("缓存读判定", t, func() {
+		auth := &countingAuthorization{}
+		c := NewCachedAuthorization(auth, WithCacheTTL(time.Minute))
+		for i := 0; i < 3; i++ {
+			resp, err := c.Allow(context.Background(), read)
+			So(err, ShouldBeNil)
+			So(resp.Allow, ShouldBeTrue)
+		}
+		So(atomic.LoadInt32(&auth.calls), ShouldEqual, 1)
+	})
+
+	Convey("不缓存写判定", t, func() {
+		auth := &countingAuthorization{}
+		c := NewCachedAuthorization(auth)
+		for i := 0; i < 3; i++ {
+			_, _ = c.Allow(context.Background(), write)
+		}
+		So(atomic.LoadInt32(&auth.calls), ShouldEqual, 3)
+	})
+
+	Convey("合并并发的相同请求", t, func() {
+		auth := &countingAuthorization{delay: 50 * time.Millisecond}
+		c := NewCachedAuthorization(auth)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _ = c.Allow(context.Background(), write)
+			}()
+		}
+		wg.Wait()
+		So(atomic.LoadInt32(&auth.calls), ShouldEqual, 1)
+	})
+
+	Convey("不合并授予不同角色的请求", t, func() {
+		auth := &countingAuthorization{delay: 50 * time.Millisecond}
+		c := NewCachedAuthorization(auth)
+		var wg sync.WaitGroup
+		for _, role := range []*Role{
+			{Type: "communityAdmin", CommunityId: "CommunityId"},
+			{Type: "superAdmin"},
+		} {
+			wg.Add(1)
+			go func(role *Role) {
+				defer wg.Done()
+				_, _ = c.Allow(context.Background(), &AllowReq{
+					UserId:   "UserId",
+					Object:   ObjectRole,
+					ObjectId: "AnotherUserId",
+					Action:   ActionWrite,
+					Role:     role,
+				})
+			}(role)
+		}
+		wg.Wait()
+		So(atomic.LoadInt32(&auth.calls), ShouldEqual, 2)
+	})
+
+	Convey("不同用户 token 的判定不共用缓存", t, func() {
+		auth := &tokenAuthorization{}
+		c := NewCachedAuthorization(auth, WithCacheTTL(time.Minute))
+		anonymous := &AllowReq{Object: ObjectUser, ObjectId: "alice", Action: ActionRead}
+
+		resp, err := c.Allow(WithUserToken(context.Background(), "alice-token"), anonymous)
+		So(err, ShouldBeNil)
+		So(resp.Allow, ShouldBeTrue)
+
+		resp, err = c.Allow(WithUserToken(context.Background(), "bob-token"), anonymous)
+		So(err, ShouldBeNil)
+		So(resp.Allow, ShouldBeFalse)
+
+		resp, err = c.Allow(context.Background(), anonymous)
+		So(err, ShouldBeNil)
+		So(resp.Allow, ShouldBeFalse)
+		So(atomic.LoadInt32(&auth.calls), ShouldEqual, 3)
+
+		// 相同 token 仍然使用缓存
+		resp, _ = c.Allow(WithUserToken(context.Background(), "alice-token"), anonymous)
+		So(resp.Allow, ShouldBeTrue)
+		So(atomic.LoadInt32(&auth.calls), ShouldEqual, 3)
+	})
+}
@@ -0,0 +1,31 @@
+package authorization
+
+import (
+	"context"
+
+	"github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/zeromicro/go-zero/zrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// WithCallerToken 在每次调用时携带调用方服务的共享密钥
+func WithCallerToken(token string) zrpc.ClientOption {
+	return zrpc.WithDialOption(grpc.WithPerRPCCredentials(callerToken(token)))
+}
+
+type callerToken string
+
+func (t callerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{constant.MetadataCallerToken: string(t)}, nil
+}
+
+// RequireTransportSecurity 允许在集群内网的明文连接上使用
+func (t callerToken) RequireTransportSecurity() bool {
+	return false
+}
+
+// WithUserToken 在本次调用中携带终端用户的 JWT，服务端据此确定 userId
+func WithUserToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, constant.MetadataUserToken, token)
+}
@@ -0,0 +1,107 @@
+package authorization
+
+import (
+	"context"
+
+	"github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var ErrPermissionDenied = status.Error(codes.PermissionDenied, "permission denied")
+
+type (
+	// Rule 描述一个 gRPC 方法需要的权限
+	Rule struct {
+		Object string
+		Action string
+		// 从请求中取对象id，为nil时对象id为空
+		ObjectId func(req interface{}) string
+		// 新建对象时的从属对象，如在帖子下新建评论
+		ParentObject string
+		ParentId     func(req interface{}) string
+	}
+
+	// Rules 的键为 gRPC 方法全名，如 /post.post_rpc/UpdatePost
+	Rules map[string]Rule
+
+	// InterceptorOption 自定义拦截器
+	InterceptorOption func(*interceptorOptions)
+
+	interceptorOptions struct {
+		userId func(ctx context.Context, req interface{}) string
+	}
+
+	userIdGetter interface {
+		GetUserId() string
+	}
+)
+
+// WithUserIdFunc 设置从请求中取用户id的方法，默认使用请求的 GetUserId
+func WithUserIdFunc(fn func(ctx context.Context, req interface{}) string) InterceptorOption {
+	return func(o *interceptorOptions) {
+		o.userId = fn
+	}
+}
+
+// UnaryServerInterceptor 按 rules 在处理请求前调用 Allow，不允许时返回 PermissionDenied
+//  不在 rules 中的方法不检查；请求携带的用户令牌会转发给授权服务
+func UnaryServerInterceptor(auth Authorization, rules Rules, opts ...InterceptorOption) grpc.UnaryServerInterceptor {
+	o := interceptorOptions{
+		userId: defaultUserId,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		rule, ok := rules[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		in := &AllowReq{
+			UserId:       o.userId(ctx, req),
+			Object:       rule.Object,
+			Action:       rule.Action,
+			ParentObject: rule.ParentObject,
+		}
+		if rule.ObjectId != nil {
+			in.ObjectId = rule.ObjectId(req)
+		}
+		if rule.ParentId != nil {
+			in.ParentId = rule.ParentId(req)
+		}
+
+		resp, err := auth.Allow(forwardUserToken(ctx), in)
+		if err != nil {
+			return nil, err
+		}
+		if !resp.Allow {
+			return nil, ErrPermissionDenied
+		}
+		return handler(ctx, req)
+	}
+}
+
+func defaultUserId(_ context.Context, req interface{}) string {
+	if r, ok := req.(userIdGetter); ok {
+		return r.GetUserId()
+	}
+	return ""
+}
+
+// 将上游请求中的用户令牌放入调用授权服务的上下文
+func forwardUserToken(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	if values := md.Get(constant.MetadataUserToken); len(values) > 0 {
+		return WithUserToken(ctx, values[0])
+	}
+	return ctx
+}
@@ -0,0 +1,68 @@
+package authorization
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type recordingAuthorization struct {
+	Authorization
+	req   *AllowReq
+	allow bool
+}
+
+func (a *recordingAuthorization) Allow(_ context.Context, in *AllowReq, _ ...grpc.CallOption) (*AllowResp, error) {
+	a.req = in
+	return &AllowResp{Allow: a.allow}, nil
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	rules := Rules{
+		"/post.post_rpc/UpdatePost": {
+			Object: ObjectPost,
+			Action: ActionWrite,
+			ObjectId: func(req interface{}) string {
+				return req.(*AllowReq).ObjectId
+			},
+		},
+	}
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		return "handled", nil
+	}
+	// 用 AllowReq 代替下游服务的请求，它同样有 GetUserId
+	req := &AllowReq{UserId: "UserId", ObjectId: "PostId"}
+
+	Convey("允许时继续处理请求", t, func() {
+		auth := &recordingAuthorization{allow: true}
+		resp, err := UnaryServerInterceptor(auth, rules)(context.Background(), req,
+			&grpc.UnaryServerInfo{FullMethod: "/post.post_rpc/UpdatePost"}, handler)
+		So(err, ShouldBeNil)
+		So(resp, ShouldEqual, "handled")
+		So(auth.req.UserId, ShouldEqual, "UserId")
+		So(auth.req.Object, ShouldEqual, ObjectPost)
+		So(auth.req.ObjectId, ShouldEqual, "PostId")
+		So(auth.req.Action, ShouldEqual, ActionWrite)
+	})
+
+	Convey("不允许时返回 PermissionDenied", t, func() {
+		auth := &recordingAuthorization{}
+		_, err := UnaryServerInterceptor(auth, rules)(context.Background(), req,
+			&grpc.UnaryServerInfo{FullMethod: "/post.post_rpc/UpdatePost"}, handler)
+		So(status.Code(err), ShouldEqual, codes.PermissionDenied)
+	})
+
+	Convey("不检查未配置的方法", t, func() {
+		auth := &recordingAuthorization{}
+		resp, err := UnaryServerInterceptor(auth, rules)(context.Background(), req,
+			&grpc.UnaryServerInfo{FullMethod: "/post.post_rpc/RetrievePost"}, handler)
+		So(err, ShouldBeNil)
+		So(resp, ShouldEqual, "handled")
+		So(auth.req, ShouldBeNil)
+	})
+}
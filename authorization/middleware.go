@@ -0,0 +1,169 @@
+package authorization
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/rest"
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"github.com/zeromicro/go-zero/rest/pathvar"
+)
+
+const (
+	defaultUserIdClaim = "userId"
+	// 请求体中取对象id时最多读取的字节数
+	maxBodyBytes = 8 << 20
+)
+
+const (
+	ReasonDenied       = "permission denied"
+	ReasonUnavailable  = "authorization unavailable"
+	ReasonBodyTooLarge = "request body too large"
+)
+
+var errBodyTooLarge = errors.New(ReasonBodyTooLarge)
+
+type (
+	// Route 是路由上的权限注解，对象id依次从路径参数和 json 请求体中查找
+	Route struct {
+		Object string
+		Action string
+		// 对象id所在的路径参数，如 /post/:id 中的 id
+		PathParam string `json:",optional"`
+		// 对象id所在的 json 请求体字段
+		BodyField string `json:",optional"`
+		// 新建对象时的从属对象，从属对象id的查找方式与对象id相同
+		ParentObject    string `json:",optional"`
+		ParentPathParam string `json:",optional"`
+		ParentBodyField string `json:",optional"`
+	}
+
+	// MiddlewareOption 自定义中间件
+	MiddlewareOption func(*middlewareOptions)
+
+	middlewareOptions struct {
+		userIdClaim string
+	}
+
+	// Denial 是拒绝请求时返回的响应体
+	Denial struct {
+		Reason string `json:"reason"`
+	}
+)
+
+// WithUserIdClaim 设置 JWT 中用户id的字段名，默认为 userId
+func WithUserIdClaim(claim string) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.userIdClaim = claim
+	}
+}
+
+// NewMiddleware 返回检查单个路由权限的 go-zero rest 中间件
+//  用户id取自 rest.WithJwt 写入上下文的字段，未登录时按匿名用户判定
+//  不允许时返回 403，授权服务不可用时返回 503，响应体均为 Denial
+//  需要从请求体取对象id而请求体超过 8 MiB 时返回 413
+func NewMiddleware(auth Authorization, route Route, opts ...MiddlewareOption) rest.Middleware {
+	o := middlewareOptions{
+		userIdClaim: defaultUserIdClaim,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			body, err := peekJsonBody(r, route)
+			if err == errBodyTooLarge {
+				httpx.WriteJsonCtx(r.Context(), w, http.StatusRequestEntityTooLarge, Denial{Reason: ReasonBodyTooLarge})
+				return
+			}
+			if err != nil {
+				httpx.WriteJsonCtx(r.Context(), w, http.StatusBadRequest, Denial{Reason: err.Error()})
+				return
+			}
+
+			in := &AllowReq{
+				UserId:       claimString(r, o.userIdClaim),
+				Object:       route.Object,
+				ObjectId:     param(r, body, route.PathParam, route.BodyField),
+				Action:       route.Action,
+				ParentObject: route.ParentObject,
+				ParentId:     param(r, body, route.ParentPathParam, route.ParentBodyField),
+			}
+			resp, err := auth.Allow(r.Context(), in)
+			if err != nil {
+				logx.WithContext(r.Context()).Errorf("[authorization] allow failed, err: %v", err)
+				httpx.WriteJsonCtx(r.Context(), w, http.StatusServiceUnavailable, Denial{Reason: ReasonUnavailable})
+				return
+			}
+			if !resp.Allow {
+				httpx.WriteJsonCtx(r.Context(), w, http.StatusForbidden, Denial{Reason: ReasonDenied})
+				return
+			}
+			next(w, r)
+		}
+	}
+}
+
+// 需要时读取 json 请求体，并还原给后续的处理器
+//  请求体超过 maxBodyBytes 时返回 errBodyTooLarge，避免截断后的请求体被传给后续的处理器
+func peekJsonBody(r *http.Request, route Route) (map[string]interface{}, error) {
+	if route.BodyField == "" && route.ParentBodyField == "" || r.Body == nil {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxBodyBytes {
+		return nil, errBodyTooLarge
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	// 数字按原文保留，否则较大的数字 id 会被转成科学计数法
+	var body map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(&body); err != nil {
+		return nil, fmt.Errorf("invalid json body: %w", err)
+	}
+	// 与 json.Unmarshal 一样不允许 json 后还有其他内容
+	if _, err = decoder.Token(); err != io.EOF {
+		return nil, errors.New("invalid json body: unexpected data after top-level value")
+	}
+	return body, nil
+}
+
+func param(r *http.Request, body map[string]interface{}, pathParam, bodyField string) string {
+	if pathParam != "" {
+		if v, ok := pathvar.Vars(r)[pathParam]; ok {
+			return v
+		}
+	}
+	if bodyField != "" {
+		if v, ok := body[bodyField]; ok && v != nil {
+			return fmt.Sprint(v)
+		}
+	}
+	return ""
+}
+
+func claimString(r *http.Request, claim string) string {
+	switch v := r.Context().Value(claim).(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,125 @@
+package authorization
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/fixture"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/server"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	"github.com/zeromicro/go-zero/rest/pathvar"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type connClient struct {
+	conn *grpc.ClientConn
+}
+
+func (c connClient) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
+// 启动进程内的授权服务，上游服务由 World 代替
+func newInProcessAuthorization(t *testing.T, w *fixture.World) Authorization {
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	pb.RegisterAuthorizationServer(s, server.NewAuthorizationServer(fixture.NewServiceContext(config.Config{}, w)))
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return NewAuthorization(connClient{conn: conn})
+}
+
+func TestMiddleware(t *testing.T) {
+	auth := newInProcessAuthorization(t, &fixture.World{
+		Users: []fixture.User{{Id: "alice"}, {Id: "bob"}},
+		Posts: []fixture.Post{{Id: "post1", UserId: "alice"}, {Id: "1234567", UserId: "alice"}},
+	})
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+	serve := func(m func(http.HandlerFunc) http.HandlerFunc, r *http.Request, userId string) *httptest.ResponseRecorder {
+		if userId != "" {
+			r = r.WithContext(context.WithValue(r.Context(), defaultUserIdClaim, userId))
+		}
+		rec := httptest.NewRecorder()
+		m(handler)(rec, r)
+		return rec
+	}
+
+	Convey("从路径参数取对象id", t, func() {
+		m := NewMiddleware(auth, Route{Object: ObjectPost, Action: ActionWrite, PathParam: "id"})
+		r := pathvar.WithVars(httptest.NewRequest(http.MethodPut, "/post/post1", nil), map[string]string{"id": "post1"})
+
+		So(serve(m, r, "alice").Code, ShouldEqual, http.StatusOK)
+
+		rec := serve(m, r, "bob")
+		So(rec.Code, ShouldEqual, http.StatusForbidden)
+		So(rec.Body.String(), ShouldContainSubstring, ReasonDenied)
+	})
+
+	Convey("从请求体取对象id，并保留请求体", t, func() {
+		m := NewMiddleware(auth, Route{Object: ObjectPost, Action: ActionWrite, BodyField: "id"})
+		var body string
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			body = string(data)
+		}
+		r := httptest.NewRequest(http.MethodPost, "/post/update", strings.NewReader(`{"id":"post1"}`))
+		r = r.WithContext(context.WithValue(r.Context(), defaultUserIdClaim, "alice"))
+		rec := httptest.NewRecorder()
+		m(handler)(rec, r)
+		So(rec.Code, ShouldEqual, http.StatusOK)
+		So(body, ShouldEqual, `{"id":"post1"}`)
+	})
+
+	Convey("请求体中的数字id按原文传递", t, func() {
+		m := NewMiddleware(auth, Route{Object: ObjectPost, Action: ActionWrite, BodyField: "id"})
+		r := httptest.NewRequest(http.MethodPost, "/post/update", strings.NewReader(`{"id":1234567}`))
+		So(serve(m, r, "alice").Code, ShouldEqual, http.StatusOK)
+	})
+
+	Convey("请求体不是单个 json 时返回 400", t, func() {
+		m := NewMiddleware(auth, Route{Object: ObjectPost, Action: ActionWrite, BodyField: "id"})
+		r := httptest.NewRequest(http.MethodPost, "/post/update", strings.NewReader(`{"id":"post1"}{}`))
+		So(serve(m, r, "alice").Code, ShouldEqual, http.StatusBadRequest)
+	})
+
+	Convey("请求体过大时返回 413", t, func() {
+		m := NewMiddleware(auth, Route{Object: ObjectPost, Action: ActionWrite, BodyField: "id"})
+		body := `{"id":"post1","content":"` + strings.Repeat("a", maxBodyBytes) + `"}`
+		r := httptest.NewRequest(http.MethodPost, "/post/update", strings.NewReader(body))
+		rec := serve(m, r, "alice")
+		So(rec.Code, ShouldEqual, http.StatusRequestEntityTooLarge)
+		So(rec.Body.String(), ShouldContainSubstring, ReasonBodyTooLarge)
+	})
+
+	Convey("未登录时按匿名用户判定", t, func() {
+		m := NewMiddleware(auth, Route{Object: ObjectPost, Action: ActionWrite, PathParam: "id"})
+		r := pathvar.WithVars(httptest.NewRequest(http.MethodPut, "/post/post1", nil), map[string]string{"id": "post1"})
+		So(serve(m, r, "").Code, ShouldEqual, http.StatusForbidden)
+	})
+}
@@ -8,9 +8,50 @@ const (
 	ObjectNews      = "news"
 	ObjectCat       = "cat"
 	ObjectMoment    = "moment"
+	ObjectUser      = "user"
+	ObjectAdmin     = "admin"
+	ObjectRole      = "role"
+	ObjectLike      = "like"
+	ObjectFollow    = "follow"
+	ObjectReport    = "report"
 )
 
+// 未登录的用户
+const AnonymousUserId = ""
+
 const (
 	ActionRead  = "read"
 	ActionWrite = "write"
 )
+
+// 请求 metadata 中的键
+const (
+	// 调用方服务的共享密钥
+	MetadataCallerToken = "x-caller-token"
+	// 终端用户的 JWT
+	MetadataUserToken = "x-user-token"
+)
+
+// 链路追踪 span 的属性
+const (
+	TraceObject   = "authorization.object"
+	TraceAction   = "authorization.action"
+	TraceDecision = "authorization.decision"
+	TraceDegraded = "authorization.degraded"
+	// 查询的上游服务或存储
+	TraceUpstream = "authorization.upstream"
+	// 缓存命中时为 hit，未命中时为 miss
+	TraceCache = "authorization.cache"
+)
+
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+// 用户资料的可见性
+const (
+	ProfilePublic    = "public"
+	ProfileFollowers = "followers"
+	ProfilePrivate   = "private"
+)
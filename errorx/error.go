@@ -0,0 +1,10 @@
+package errorx
+
+import "google.golang.org/grpc/status"
+
+var (
+	ErrInvalidArgs = status.Error(10801, "invalid args")
+	ErrBlockSelf   = status.Error(10802, "cannot block yourself")
+	// 操作的用户没有权限
+	ErrPermissionDenied = status.Error(10803, "permission denied")
+)
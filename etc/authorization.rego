@@ -0,0 +1,63 @@
+package authorization
+
+# 与内置策略等价的 rego 策略，可作为迁移的起点
+# role、comment、like、follow 和 report 始终由内置策略判定，不会进入本策略
+#
+# input:
+#   object, objectId, action: 请求
+#   user: {id, roles: [{type, communityId}]}
+#   resource: 对象属性，对象不存在时为 null
+#
+# 匿名用户的请求按 Anonymous 配置判定，不会进入本策略
+# 启用 PrivateCommunity 时，私有社区的读限制在本策略之后生效
+#     community: {id, parentId}  社区下的对象
+#     ownerId                    发布者
+
+default allow = false
+
+allow {
+	input.action == "read"
+}
+
+allow {
+	input.action == "write"
+	super_admin
+}
+
+allow {
+	input.action == "write"
+	writable(input.object, input.resource)
+}
+
+super_admin {
+	input.user.roles[_].type == "superAdmin"
+}
+
+# 是否是社区或其父社区的管理员
+manages(community) {
+	role := input.user.roles[_]
+	role.type == "communityAdmin"
+	role.communityId == community.id
+}
+
+manages(community) {
+	role := input.user.roles[_]
+	role.type == "communityAdmin"
+	role.communityId == community.parentId
+}
+
+owns(resource) {
+	resource.ownerId == input.user.id
+}
+
+community_objects := {"community", "notice", "news", "cat", "moment"}
+
+writable(object, resource) {
+	community_objects[object]
+	manages(resource.community)
+}
+
+writable(object, resource) {
+	object != "community"
+	owns(resource)
+}
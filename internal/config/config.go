@@ -1,6 +1,271 @@
 package config
 
-import "github.com/zeromicro/go-zero/zrpc"
+import (
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/zrpc"
+)
+
+const (
+	EngineBuiltin = "builtin"
+	EngineRego    = "rego"
+	EngineCasbin  = "casbin"
+)
+
+type RegoConf struct {
+	// rego 策略文件或目录
+	Path string `json:",optional"`
+	// 判定结果的查询语句，结果应为 bool
+	Query string `json:",default=data.authorization.allow"`
+	// 交给 rego 判定的对象类型，为空时所有对象都交给 rego
+	Objects []string `json:",optional"`
+}
+
+type CasbinConf struct {
+	// 模型文件，域为社区
+	Model string `json:",optional"`
+	// 策略文件，只包含 p 规则，sub 为角色，用户的角色由 system-rpc 和授予生成
+	Policy string `json:",optional"`
+	// 交给 casbin 判定的对象类型，为空时所有对象都交给 casbin
+	Objects []string `json:",optional"`
+}
+
+type PolicyConf struct {
+	Engine string     `json:",default=builtin,options=builtin|rego|casbin"`
+	Rego   RegoConf   `json:",optional"`
+	Casbin CasbinConf `json:",optional"`
+}
+
+type ShadowConf struct {
+	// 判定影子策略集的协程数
+	Workers int `json:",default=4"`
+	// 等待判定的请求数上限，队列已满时丢弃新的请求
+	QueueSize int `json:",default=1000"`
+	// 抽样判定的比例，取值 (0, 1]
+	SampleRate float64 `json:",default=1"`
+	// 单次判定的超时
+	Timeout time.Duration `json:",default=1s"`
+}
+
+type CallerConf struct {
+	// 调用方服务名，使用 mTLS 时与客户端证书的 CommonName 匹配
+	Name string
+	// 共享密钥，为空时该调用方只能通过 mTLS 认证
+	Token string `json:",optional"`
+}
+
+type TLSConf struct {
+	CertFile string
+	KeyFile  string
+	// 用于校验客户端证书的 CA
+	CAFile string
+}
+
+type CallerAuthConf struct {
+	// 允许调用的服务，为空时不校验调用方
+	Callers []CallerConf `json:",optional"`
+	// 配置后服务端启用 TLS 并校验客户端证书
+	TLS *TLSConf `json:",optional"`
+}
+
+// Enabled 判断是否需要校验调用方
+func (c CallerAuthConf) Enabled() bool {
+	return len(c.Callers) > 0
+}
+
+const (
+	UserTokenOff      = "off"
+	UserTokenOptional = "optional"
+	UserTokenRequired = "required"
+)
+
+type UserTokenConf struct {
+	// off: 不校验; optional: 携带令牌时校验; required: 必须携带令牌
+	Mode string `json:",default=off,options=off|optional|required"`
+	// HMAC 签名的密钥
+	Secret string `json:",optional"`
+	// 本地 JWKS 文件，用于 RSA、ECDSA 签名
+	JwksFile string `json:",optional"`
+}
+
+type LimitConf struct {
+	// 每秒补充的令牌数，为 0 时不限流
+	Rate float64 `json:",optional"`
+	// 桶容量，为 0 时取 Rate 向上取整
+	Burst int `json:",optional"`
+}
+
+type CallerLimitConf struct {
+	Name string
+	LimitConf
+}
+
+type RateLimitConf struct {
+	// 每个调用方服务的默认限额，未启用调用方认证时所有请求共用一个桶
+	Caller LimitConf `json:",optional"`
+	// 单独配置的调用方限额
+	Callers []CallerLimitConf `json:",optional"`
+	// 每个 userId 的限额，匿名请求不限
+	User LimitConf `json:",optional"`
+}
+
+type AnonymousRule struct {
+	Object  string
+	Actions []string
+}
+
+type AnonymousConf struct {
+	// 匿名用户可以执行的操作，未配置时可以读所有对象、不能写
+	Rules []AnonymousRule `json:",optional"`
+}
+
+type ContentStatusConf struct {
+	// 只允许发布者和管理员读的帖子状态，如审核中 0、未过审 2
+	Hidden []int64 `json:",optional"`
+	// 只允许发布者读的帖子状态
+	Draft []int64 `json:",optional"`
+}
+
+type EditWindowConf struct {
+	Object string
+	// 发布后允许发布者修改的时长，如 24h
+	Duration time.Duration
+}
+
+const (
+	// 拒绝
+	DegradeClosed = "closed"
+	// 允许读，写仍然拒绝
+	DegradeOpen = "open"
+	// 使用最近一次正常判定的结果，没有时拒绝
+	DegradeStale = "stale"
+)
+
+type DegradeRule struct {
+	Object string
+	// 为空时匹配所有操作
+	Action string `json:",optional"`
+	Mode   string `json:",options=closed|open|stale"`
+}
+
+type DegradeConf struct {
+	// 未匹配规则时的降级方式
+	Mode  string        `json:",default=closed,options=closed|open|stale"`
+	Rules []DegradeRule `json:",optional"`
+	// 用于 stale 的判定结果保留时长
+	StaleTTL time.Duration `json:",default=10m"`
+	// 最多保留的判定结果数
+	StaleLimit int `json:",default=100000"`
+}
+
+// ModeOf 返回请求的降级方式，先匹配的规则优先
+func (c DegradeConf) ModeOf(object, action string) string {
+	for _, r := range c.Rules {
+		if r.Object == object && (r.Action == "" || r.Action == action) {
+			return r.Mode
+		}
+	}
+	if c.Mode == "" {
+		return DegradeClosed
+	}
+	return c.Mode
+}
+
+// UsesStale 判断是否需要保留判定结果
+func (c DegradeConf) UsesStale() bool {
+	if c.Mode == DegradeStale {
+		return true
+	}
+	for _, r := range c.Rules {
+		if r.Mode == DegradeStale {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	UpstreamCollection = "collection"
+	UpstreamMoment     = "moment"
+	UpstreamSystem     = "system"
+	UpstreamComment    = "comment"
+	UpstreamPost       = "post"
+)
+
+type BreakerConf struct {
+	// 连续失败达到次数后熔断，为 0 时不熔断
+	Failures int `json:",default=5"`
+	// 熔断持续时长，之后放行一个探测请求，成功后恢复
+	Cooldown time.Duration `json:",default=10s"`
+	// 熔断期间使用的上游查询结果的保留时长，为 0 时不保留，熔断期间直接按降级处理
+	FactTTL time.Duration `json:",optional"`
+	// 最多保留的查询结果数
+	FactLimit int `json:",default=100000"`
+}
+
+type UpstreamBreakerConf struct {
+	// collection、moment、system、comment 或 post
+	Name string `json:",options=collection|moment|system|comment|post"`
+	BreakerConf
+}
+
+type BreakersConf struct {
+	// 所有上游服务的默认熔断配置
+	Default BreakerConf `json:",optional"`
+	// 单独配置的上游服务
+	Upstreams []UpstreamBreakerConf `json:",optional"`
+}
+
+// Of 返回上游服务的熔断配置
+func (c BreakersConf) Of(name string) BreakerConf {
+	for _, u := range c.Upstreams {
+		if u.Name == name {
+			return u.BreakerConf
+		}
+	}
+	return c.Default
+}
+
+type HealthCheckConf struct {
+	// 检查上游服务状态的间隔
+	Interval time.Duration `json:",default=5s"`
+	// 不可用时整个服务报告 NOT_SERVING 的上游服务，未配置时只有 system
+	Critical []string `json:",optional"`
+}
+
+// CriticalSet 返回关键上游服务的集合
+func (c HealthCheckConf) CriticalSet() map[string]bool {
+	critical := c.Critical
+	if len(critical) == 0 {
+		critical = []string{UpstreamSystem}
+	}
+	set := make(map[string]bool, len(critical))
+	for _, name := range critical {
+		set[name] = true
+	}
+	return set
+}
+
+type UserProfileConf struct {
+	// 未设置可见性的用户资料的默认可见性
+	Visibility string `json:",default=public,options=public|followers|private"`
+}
+
+type InteractionConf struct {
+	// 不允许给自己发布的内容点赞的对象类型，如 post、moment
+	NoSelfLike []string `json:",optional"`
+}
+
+// SelfLike 判断是否允许给自己发布的某类对象点赞
+func (c InteractionConf) SelfLike(object string) bool {
+	for _, o := range c.NoSelfLike {
+		if o == object {
+			return false
+		}
+	}
+	return true
+}
 
 type Config struct {
 	zrpc.RpcServerConf
@@ -9,4 +274,34 @@ type Config struct {
 	SystemRPC     zrpc.RpcClientConf
 	CommentRPC    zrpc.RpcClientConf
 	PostRPC       zrpc.RpcClientConf
+	Mongo         struct {
+		URL string
+		DB  string
+	}
+	CacheConf cache.CacheConf
+	Policy    PolicyConf `json:",optional"`
+	// 影子策略集，配置后会在线上流量上与 Policy 同时判定，只记录不一致的结果
+	ShadowPolicy PolicyConf `json:",optional"`
+	// 影子策略集的并发、抽样和超时
+	Shadow     ShadowConf     `json:",optional"`
+	CallerAuth CallerAuthConf `json:",optional"`
+	UserToken  UserTokenConf  `json:",optional"`
+	RateLimit  RateLimitConf  `json:",optional"`
+	Anonymous  AnonymousConf  `json:",optional"`
+	// 启用后私有社区中的对象只允许成员和管理员读，读请求需要额外查询对象所属社区
+	PrivateCommunity bool `json:",optional"`
+	// 按帖子状态限制读，未配置时不限制
+	ContentStatus ContentStatusConf `json:",optional"`
+	// 帖子、动态、评论等对象的修改期限，对所有策略引擎生效，未配置的对象不限制
+	EditWindows []EditWindowConf `json:",optional"`
+	// 上游服务或存储不可用时的判定方式
+	Degrade DegradeConf `json:",optional"`
+	// 上游服务的熔断，未配置时不熔断
+	Breakers BreakersConf `json:",optional"`
+	// 标准 gRPC 健康检查，按上游服务状态报告，代替 go-zero 自带的健康检查
+	HealthCheck HealthCheckConf `json:",optional"`
+	// 用户资料的读权限，未配置时默认公开
+	UserProfile UserProfileConf `json:",optional"`
+	// 点赞、关注和举报的限制
+	Interaction InteractionConf `json:",optional"`
 }
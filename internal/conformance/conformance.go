@@ -0,0 +1,85 @@
+package conformance
+
+import (
+	"context"
+
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/fixture"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/logic"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	"github.com/zeromicro/go-zero/core/conf"
+)
+
+type (
+	// Suite 是一组判定用例，用例在 World 描述的用户、社区和内容上判定
+	Suite struct {
+		fixture.Settings
+		World fixture.World `json:",optional"`
+		Cases []Case
+	}
+
+	// Case 是一次 Allow 请求和期望的判定
+	Case struct {
+		Name         string
+		UserId       string `json:",optional"`
+		Object       string
+		ObjectId     string `json:",optional"`
+		Action       string `json:",options=read|write"`
+		ParentObject string `json:",optional"`
+		ParentId     string `json:",optional"`
+		// 修改用户角色时授予或撤销的角色
+		Role  *fixture.Role `json:",optional"`
+		Allow bool
+	}
+
+	// Result 是一个用例的判定结果
+	Result struct {
+		Case
+		Decision bool
+	}
+)
+
+// Load 从 yaml 或 json 文件加载用例
+func Load(path string) (*Suite, error) {
+	var s Suite
+	if err := conf.Load(path, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Passed 判断判定是否与期望一致
+func (r Result) Passed() bool {
+	return r.Decision == r.Allow
+}
+
+// Evaluate 使用 World 代替上游服务和存储，依次判定所有用例
+func (s *Suite) Evaluate() []Result {
+	svcCtx := fixture.NewServiceContext(s.Config(), &s.World)
+	results := make([]Result, 0, len(s.Cases))
+	for _, c := range s.Cases {
+		resp, _ := logic.NewAllowLogic(context.Background(), svcCtx).Allow(c.request())
+		results = append(results, Result{
+			Case:     c,
+			Decision: resp != nil && resp.Allow,
+		})
+	}
+	return results
+}
+
+func (c Case) request() *pb.AllowReq {
+	in := &pb.AllowReq{
+		UserId:       c.UserId,
+		ObjectId:     c.ObjectId,
+		Object:       c.Object,
+		Action:       c.Action,
+		ParentObject: c.ParentObject,
+		ParentId:     c.ParentId,
+	}
+	if c.Role != nil {
+		in.Role = &pb.Role{
+			Type:        c.Role.Type,
+			CommunityId: c.Role.CommunityId,
+		}
+	}
+	return in
+}
@@ -0,0 +1,36 @@
+package conformance
+
+import (
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConformance(t *testing.T) {
+	files, err := filepath.Glob("testdata/*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no conformance suites in testdata")
+	}
+
+	for _, file := range files {
+		s, err := Load(file)
+		if err != nil {
+			t.Fatalf("load %s: %v", file, err)
+		}
+		results := s.Evaluate()
+
+		Convey(file, t, func() {
+			So(results, ShouldHaveLength, len(s.Cases))
+			for _, r := range results {
+				r := r
+				Convey(r.Name, func() {
+					So(r.Decision, ShouldEqual, r.Allow)
+				})
+			}
+		})
+	}
+}
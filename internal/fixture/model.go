@@ -0,0 +1,132 @@
+package fixture
+
+import (
+	"context"
+
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+)
+
+// 只读的授予存储，写操作未实现
+type grantModel struct {
+	model.GrantModel
+	w *World
+}
+
+func (m *grantModel) ListByUserId(_ context.Context, userId string) ([]*model.Grant, error) {
+	var resp []*model.Grant
+	for _, g := range m.w.Grants {
+		if g.UserId == userId {
+			resp = append(resp, &model.Grant{
+				UserId:      g.UserId,
+				Role:        g.Role,
+				CommunityId: g.CommunityId,
+			})
+		}
+	}
+	return resp, nil
+}
+
+// 只读的成员存储
+type memberModel struct {
+	model.MemberModel
+	w *World
+}
+
+func (m *memberModel) FindOneByUserIdAndCommunityId(_ context.Context, userId, communityId string) (*model.Member, error) {
+	for _, member := range m.w.Members {
+		if member.UserId == userId && member.CommunityId == communityId {
+			return &model.Member{UserId: userId, CommunityId: communityId}, nil
+		}
+	}
+	return nil, model.ErrNotFound
+}
+
+// 只读的可见性存储，数据来自 World.Communities
+type visibilityModel struct {
+	model.VisibilityModel
+	w *World
+}
+
+func (m *visibilityModel) FindOneByCommunityId(_ context.Context, communityId string) (*model.Visibility, error) {
+	for _, c := range m.w.Communities {
+		if c.Id == communityId && c.Private {
+			return &model.Visibility{CommunityId: communityId, Private: true}, nil
+		}
+	}
+	return nil, model.ErrNotFound
+}
+
+// 只读的拉黑存储
+type blockModel struct {
+	model.BlockModel
+	w *World
+}
+
+func (m *blockModel) FindOneByUserIdAndBlockedUserId(_ context.Context, userId, blockedUserId string) (*model.Block, error) {
+	for _, b := range m.w.Blocks {
+		if b.UserId == userId && b.BlockedUserId == blockedUserId {
+			return &model.Block{UserId: userId, BlockedUserId: blockedUserId}, nil
+		}
+	}
+	return nil, model.ErrNotFound
+}
+
+// 只读的锁定存储
+type lockModel struct {
+	model.LockModel
+	w *World
+}
+
+func (m *lockModel) FindOneByObjectAndObjectId(_ context.Context, object, objectId string) (*model.Lock, error) {
+	for _, l := range m.w.Locks {
+		if l.Object == object && l.ObjectId == objectId {
+			return &model.Lock{Object: object, ObjectId: objectId}, nil
+		}
+	}
+	return nil, model.ErrNotFound
+}
+
+// 只读的用户资料可见性存储
+type profileModel struct {
+	model.ProfileModel
+	w *World
+}
+
+func (m *profileModel) FindOneByUserId(_ context.Context, userId string) (*model.Profile, error) {
+	for _, u := range m.w.Users {
+		if u.Id == userId && u.Visibility != "" {
+			return &model.Profile{UserId: userId, Visibility: u.Visibility}, nil
+		}
+	}
+	return nil, model.ErrNotFound
+}
+
+// 只读的关注关系存储
+type followModel struct {
+	model.FollowModel
+	w *World
+}
+
+func (m *followModel) FindOneByUserIdAndFollowedUserId(_ context.Context, userId, followedUserId string) (*model.Follow, error) {
+	for _, f := range m.w.Follows {
+		if f.UserId == userId && f.FollowedUserId == followedUserId {
+			return &model.Follow{UserId: userId, FollowedUserId: followedUserId}, nil
+		}
+	}
+	return nil, model.ErrNotFound
+}
+
+// 只读的举报存储
+type reportModel struct {
+	model.ReportModel
+	w *World
+}
+
+func (m *reportModel) FindOneOpenByUserIdAndObject(_ context.Context, userId, object, objectId string) (*model.Report, error) {
+	for _, r := range m.w.Reports {
+		if r.UserId == userId && r.Object == object && r.ObjectId == objectId && !r.Closed {
+			return &model.Report{UserId: userId, Object: object, ObjectId: objectId}, nil
+		}
+	}
+	return nil, model.ErrNotFound
+}
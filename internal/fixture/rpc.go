@@ -0,0 +1,163 @@
+package fixture
+
+import (
+	"context"
+
+	"github.com/xh-polaris/meowchat-collection-rpc/collectionrpc"
+	"github.com/xh-polaris/meowchat-comment-rpc/commentrpc"
+	"github.com/xh-polaris/meowchat-moment-rpc/momentrpc"
+	"github.com/xh-polaris/meowchat-post-rpc/postrpc"
+	"github.com/xh-polaris/meowchat-system-rpc/systemrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 以下实现只提供鉴权用到的查询方法，其余方法未实现，调用时会 panic
+
+type systemRPC struct {
+	systemrpc.SystemRpc
+	w *World
+}
+
+func (s *systemRPC) RetrieveUserRole(_ context.Context, in *systemrpc.RetrieveUserRoleReq, _ ...grpc.CallOption) (*systemrpc.RetrieveUserRoleResp, error) {
+	resp := &systemrpc.RetrieveUserRoleResp{}
+	for _, u := range s.w.Users {
+		if u.Id != in.UserId {
+			continue
+		}
+		for _, r := range u.Roles {
+			resp.Roles = append(resp.Roles, &systemrpc.Role{
+				Type:        r.Type,
+				CommunityId: r.CommunityId,
+			})
+		}
+	}
+	return resp, nil
+}
+
+func (s *systemRPC) RetrieveCommunity(_ context.Context, in *systemrpc.RetrieveCommunityReq, _ ...grpc.CallOption) (*systemrpc.RetrieveCommunityResp, error) {
+	for _, c := range s.w.Communities {
+		if c.Id == in.Id {
+			return &systemrpc.RetrieveCommunityResp{Community: &systemrpc.Community{
+				Id:       c.Id,
+				ParentId: c.ParentId,
+			}}, nil
+		}
+	}
+	return nil, notFound("community", in.Id)
+}
+
+func (s *systemRPC) RetrieveNotice(_ context.Context, in *systemrpc.RetrieveNoticeReq, _ ...grpc.CallOption) (*systemrpc.RetrieveNoticeResp, error) {
+	for _, n := range s.w.Notices {
+		if n.Id == in.Id {
+			return &systemrpc.RetrieveNoticeResp{Notice: &systemrpc.Notice{
+				Id:          n.Id,
+				CommunityId: n.CommunityId,
+			}}, nil
+		}
+	}
+	return nil, notFound("notice", in.Id)
+}
+
+func (s *systemRPC) RetrieveNews(_ context.Context, in *systemrpc.RetrieveNewsReq, _ ...grpc.CallOption) (*systemrpc.RetrieveNewsResp, error) {
+	for _, n := range s.w.News {
+		if n.Id == in.Id {
+			return &systemrpc.RetrieveNewsResp{News: &systemrpc.News{
+				Id:          n.Id,
+				CommunityId: n.CommunityId,
+			}}, nil
+		}
+	}
+	return nil, notFound("news", in.Id)
+}
+
+func (s *systemRPC) RetrieveAdmin(_ context.Context, in *systemrpc.RetrieveAdminReq, _ ...grpc.CallOption) (*systemrpc.RetrieveAdminResp, error) {
+	for _, a := range s.w.Admins {
+		if a.Id == in.Id {
+			return &systemrpc.RetrieveAdminResp{Admin: &systemrpc.Admin{
+				Id:          a.Id,
+				CommunityId: a.CommunityId,
+			}}, nil
+		}
+	}
+	return nil, notFound("admin", in.Id)
+}
+
+type collectionRPC struct {
+	collectionrpc.CollectionRpc
+	w *World
+}
+
+func (s *collectionRPC) RetrieveCat(_ context.Context, in *collectionrpc.RetrieveCatReq, _ ...grpc.CallOption) (*collectionrpc.RetrieveCatResp, error) {
+	for _, c := range s.w.Cats {
+		if c.Id == in.CatId {
+			return &collectionrpc.RetrieveCatResp{Cat: &collectionrpc.Cat{
+				Id:          c.Id,
+				CommunityId: c.CommunityId,
+			}}, nil
+		}
+	}
+	return nil, notFound("cat", in.CatId)
+}
+
+type postRPC struct {
+	postrpc.PostRpc
+	w *World
+}
+
+func (s *postRPC) RetrievePost(_ context.Context, in *postrpc.RetrievePostReq, _ ...grpc.CallOption) (*postrpc.RetrievePostResp, error) {
+	for _, p := range s.w.Posts {
+		if p.Id == in.PostId {
+			return &postrpc.RetrievePostResp{Post: &postrpc.Post{
+				Id:       p.Id,
+				UserId:   p.UserId,
+				Status:   p.Status,
+				CreateAt: p.CreateAt,
+			}}, nil
+		}
+	}
+	return nil, notFound("post", in.PostId)
+}
+
+type momentRPC struct {
+	momentrpc.MomentRpc
+	w *World
+}
+
+func (s *momentRPC) RetrieveMoment(_ context.Context, in *momentrpc.RetrieveMomentReq, _ ...grpc.CallOption) (*momentrpc.RetrieveMomentResp, error) {
+	for _, m := range s.w.Moments {
+		if m.Id == in.MomentId {
+			return &momentrpc.RetrieveMomentResp{Moment: &momentrpc.Moment{
+				Id:          m.Id,
+				UserId:      m.UserId,
+				CommunityId: m.CommunityId,
+				CreateAt:    m.CreateAt,
+			}}, nil
+		}
+	}
+	return nil, notFound("moment", in.MomentId)
+}
+
+type commentRPC struct {
+	commentrpc.CommentRpc
+	w *World
+}
+
+func (s *commentRPC) RetrieveCommentById(_ context.Context, in *commentrpc.RetrieveCommentByIdRequest, _ ...grpc.CallOption) (*commentrpc.RetrieveCommentByIdResponse, error) {
+	for _, c := range s.w.Comments {
+		if c.Id == in.Id {
+			return &commentrpc.RetrieveCommentByIdResponse{Comment: &commentrpc.Comment{
+				Id:       c.Id,
+				AuthorId: c.AuthorId,
+				Type:     c.Type,
+				ParentId: c.ParentId,
+			}}, nil
+		}
+	}
+	return nil, notFound("comment", in.Id)
+}
+
+func notFound(object, id string) error {
+	return status.Errorf(codes.NotFound, "no such %s: %s", object, id)
+}
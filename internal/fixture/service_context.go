@@ -0,0 +1,29 @@
+package fixture
+
+import (
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+)
+
+// NewServiceContext 返回由 World 代替所有上游服务和存储的 ServiceContext
+func NewServiceContext(c config.Config, w *World) *svc.ServiceContext {
+	svcCtx := &svc.ServiceContext{
+		Config:          c,
+		CollectionRPC:   &collectionRPC{w: w},
+		MomentRPC:       &momentRPC{w: w},
+		SystemRPC:       &systemRPC{w: w},
+		CommentRPC:      &commentRPC{w: w},
+		PostRPC:         &postRPC{w: w},
+		Policy:          svc.MustNewPolicy(c.Policy),
+		GrantModel:      &grantModel{w: w},
+		VisibilityModel: &visibilityModel{w: w},
+		MemberModel:     &memberModel{w: w},
+		BlockModel:      &blockModel{w: w},
+		LockModel:       &lockModel{w: w},
+		ProfileModel:    &profileModel{w: w},
+		FollowModel:     &followModel{w: w},
+		ReportModel:     &reportModel{w: w},
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+	return svcCtx
+}
@@ -0,0 +1,28 @@
+package fixture
+
+import "github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+
+// Settings 是配置中影响判定的部分，离线判定时只需要这些配置，未配置时使用默认值
+type Settings struct {
+	// 策略集，未配置时使用内置策略，文件路径相对于运行目录
+	Policy           config.PolicyConf        `json:",optional"`
+	Anonymous        config.AnonymousConf     `json:",optional"`
+	PrivateCommunity bool                     `json:",optional"`
+	ContentStatus    config.ContentStatusConf `json:",optional"`
+	EditWindows      []config.EditWindowConf  `json:",optional"`
+	UserProfile      config.UserProfileConf   `json:",optional"`
+	Interaction      config.InteractionConf   `json:",optional"`
+}
+
+// Config 返回只包含影响判定部分的服务配置
+func (s Settings) Config() config.Config {
+	return config.Config{
+		Policy:           s.Policy,
+		Anonymous:        s.Anonymous,
+		PrivateCommunity: s.PrivateCommunity,
+		ContentStatus:    s.ContentStatus,
+		EditWindows:      s.EditWindows,
+		UserProfile:      s.UserProfile,
+		Interaction:      s.Interaction,
+	}
+}
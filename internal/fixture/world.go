@@ -0,0 +1,134 @@
+package fixture
+
+import "github.com/zeromicro/go-zero/core/conf"
+
+type (
+	// World 描述一组用户、社区和内容，代替线上的 system、post、moment 等服务用于离线判定
+	World struct {
+		Users       []User      `json:",optional"`
+		Grants      []Grant     `json:",optional"`
+		Communities []Community `json:",optional"`
+		Members     []Member    `json:",optional"`
+		Blocks      []Block     `json:",optional"`
+		Follows     []Follow    `json:",optional"`
+		Reports     []Report    `json:",optional"`
+		Locks       []Lock      `json:",optional"`
+		Notices     []Notice    `json:",optional"`
+		News        []News      `json:",optional"`
+		Admins      []Admin     `json:",optional"`
+		Cats        []Cat       `json:",optional"`
+		Posts       []Post      `json:",optional"`
+		Moments     []Moment    `json:",optional"`
+		Comments    []Comment   `json:",optional"`
+	}
+
+	Role struct {
+		Type        string
+		CommunityId string `json:",optional"`
+	}
+
+	User struct {
+		Id    string
+		Roles []Role `json:",optional"`
+		// 资料的可见性，为空时使用配置的默认可见性
+		Visibility string `json:",optional"`
+	}
+
+	// Grant 对应本服务自行维护的角色授予
+	Grant struct {
+		UserId      string
+		Role        string
+		CommunityId string `json:",optional"`
+	}
+
+	Community struct {
+		Id       string
+		ParentId string `json:",optional"`
+		// 本服务维护的可见性
+		Private bool `json:",optional"`
+	}
+
+	// Block 表示 UserId 拉黑了 BlockedUserId
+	Block struct {
+		UserId        string
+		BlockedUserId string
+	}
+
+	// Follow 表示 UserId 关注了 FollowedUserId
+	Follow struct {
+		UserId         string
+		FollowedUserId string
+	}
+
+	// Report 表示 UserId 举报了对象，Closed 表示举报已处理
+	Report struct {
+		UserId   string
+		Object   string
+		ObjectId string
+		Closed   bool `json:",optional"`
+	}
+
+	// Lock 表示对象已被锁定
+	Lock struct {
+		Object   string
+		ObjectId string
+	}
+
+	// Member 是私有社区的成员
+	Member struct {
+		UserId      string
+		CommunityId string
+	}
+
+	Notice struct {
+		Id          string
+		CommunityId string
+	}
+
+	News struct {
+		Id          string
+		CommunityId string
+	}
+
+	// Admin 是社区管理员信息
+	Admin struct {
+		Id          string
+		CommunityId string
+	}
+
+	Cat struct {
+		Id          string
+		CommunityId string
+	}
+
+	Post struct {
+		Id     string
+		UserId string
+		Status int64 `json:",optional"`
+		// 发布时间，unix 秒
+		CreateAt int64 `json:",optional"`
+	}
+
+	Moment struct {
+		Id          string
+		UserId      string
+		CommunityId string
+		CreateAt    int64 `json:",optional"`
+	}
+
+	Comment struct {
+		Id       string
+		AuthorId string
+		Type     string
+		ParentId string
+	}
+)
+
+// Load 从 yaml 或 json 文件加载 World
+func Load(path string) (*World, error) {
+	var w World
+	if err := conf.Load(path, &w); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
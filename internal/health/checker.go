@@ -0,0 +1,109 @@
+package health
+
+import (
+	"time"
+
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/metric"
+	"github.com/zeromicro/go-zero/core/threading"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const defaultInterval = 5 * time.Second
+
+var upstreamUp = metric.NewGaugeVec(&metric.GaugeVecOpts{
+	Namespace: "authorization",
+	Subsystem: "upstream",
+	Name:      "up",
+	Help:      "whether the upstream service is available, reported by the health checker.",
+	Labels:    []string{"upstream"},
+})
+
+// Checker 定期检查上游服务的熔断器和连接状态，并更新标准 gRPC 健康检查服务
+//  每个上游服务以自己的名字注册，如 system；关键上游服务不可用时，整个服务（空服务名和
+//  authorization.authorization）报告 NOT_SERVING，其他上游服务不可用只影响按名字的查询
+type Checker struct {
+	*health.Server
+	upstreams svc.Upstreams
+	critical  map[string]bool
+	interval  time.Duration
+	// 上一次检查时各上游服务是否可用，只在变化时打日志
+	healthy map[string]bool
+	done    chan struct{}
+}
+
+func NewChecker(c config.HealthCheckConf, upstreams svc.Upstreams) *Checker {
+	interval := c.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	checker := &Checker{
+		Server:    health.NewServer(),
+		upstreams: upstreams,
+		critical:  c.CriticalSet(),
+		interval:  interval,
+		healthy:   make(map[string]bool, len(upstreams)),
+		done:      make(chan struct{}),
+	}
+	checker.Update()
+	return checker
+}
+
+// Start 开始定期检查，Stop 后不再更新状态
+func (c *Checker) Start() {
+	threading.GoSafe(func() {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.Update()
+			case <-c.done:
+				return
+			}
+		}
+	})
+}
+
+// Stop 停止检查，并将所有服务置为 NOT_SERVING，用于优雅退出
+func (c *Checker) Stop() {
+	close(c.done)
+	c.Shutdown()
+}
+
+// Update 检查一次所有上游服务并更新状态
+func (c *Checker) Update() {
+	serving := true
+	for _, u := range c.upstreams {
+		healthy := u.Healthy()
+		if last, ok := c.healthy[u.Name]; !ok || last != healthy {
+			c.healthy[u.Name] = healthy
+			if healthy {
+				logx.Infof("upstream %s is available", u.Name)
+			} else {
+				logx.Errorf("upstream %s is unavailable", u.Name)
+			}
+		}
+		if healthy {
+			upstreamUp.Set(1, u.Name)
+		} else {
+			upstreamUp.Set(0, u.Name)
+			serving = serving && !c.critical[u.Name]
+		}
+		c.SetServingStatus(u.Name, servingStatus(healthy))
+	}
+
+	c.SetServingStatus("", servingStatus(serving))
+	c.SetServingStatus(pb.Authorization_ServiceDesc.ServiceName, servingStatus(serving))
+}
+
+func servingStatus(serving bool) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if serving {
+		return grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+}
@@ -0,0 +1,85 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/interceptor"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestChecker(t *testing.T) {
+	newUpstream := func(name string) *svc.Upstream {
+		return &svc.Upstream{
+			Name:    name,
+			Breaker: interceptor.MustNewBreaker(name, config.BreakerConf{Failures: 1, Cooldown: time.Minute}),
+		}
+	}
+	fail := func(u *svc.Upstream) {
+		_ = u.Breaker.UnaryClientInterceptor(context.Background(), "/m", nil, nil, nil,
+			func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+				return status.Error(codes.Unavailable, "unavailable")
+			})
+	}
+	check := func(c *Checker, service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+		resp, err := c.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service})
+		So(err, ShouldBeNil)
+		return resp.Status
+	}
+
+	Convey("上游服务都可用时报告 SERVING", t, func() {
+		c := NewChecker(config.HealthCheckConf{}, svc.Upstreams{newUpstream(config.UpstreamSystem)})
+		So(check(c, ""), ShouldEqual, grpc_health_v1.HealthCheckResponse_SERVING)
+		So(check(c, "authorization.authorization"), ShouldEqual, grpc_health_v1.HealthCheckResponse_SERVING)
+		So(check(c, config.UpstreamSystem), ShouldEqual, grpc_health_v1.HealthCheckResponse_SERVING)
+	})
+
+	Convey("非关键上游服务熔断时只影响该上游服务的状态", t, func() {
+		post := newUpstream(config.UpstreamPost)
+		c := NewChecker(config.HealthCheckConf{}, svc.Upstreams{newUpstream(config.UpstreamSystem), post})
+		fail(post)
+		c.Update()
+		So(check(c, ""), ShouldEqual, grpc_health_v1.HealthCheckResponse_SERVING)
+		So(check(c, config.UpstreamPost), ShouldEqual, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	})
+
+	Convey("关键上游服务熔断时报告 NOT_SERVING", t, func() {
+		system := newUpstream(config.UpstreamSystem)
+		c := NewChecker(config.HealthCheckConf{}, svc.Upstreams{system, newUpstream(config.UpstreamPost)})
+		fail(system)
+		c.Update()
+		So(check(c, ""), ShouldEqual, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+		So(check(c, "authorization.authorization"), ShouldEqual, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+		So(check(c, config.UpstreamPost), ShouldEqual, grpc_health_v1.HealthCheckResponse_SERVING)
+	})
+
+	Convey("关键上游服务冷却结束后恢复 SERVING", t, func() {
+		system := &svc.Upstream{
+			Name: config.UpstreamSystem,
+			Breaker: interceptor.MustNewBreaker(config.UpstreamSystem,
+				config.BreakerConf{Failures: 1, Cooldown: 50 * time.Millisecond}),
+		}
+		c := NewChecker(config.HealthCheckConf{}, svc.Upstreams{system})
+		fail(system)
+		c.Update()
+		So(check(c, ""), ShouldEqual, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+		time.Sleep(60 * time.Millisecond)
+		c.Update()
+		So(check(c, ""), ShouldEqual, grpc_health_v1.HealthCheckResponse_SERVING)
+	})
+
+	Convey("退出时报告 NOT_SERVING", t, func() {
+		c := NewChecker(config.HealthCheckConf{}, svc.Upstreams{newUpstream(config.UpstreamSystem)})
+		c.Start()
+		c.Stop()
+		So(check(c, ""), ShouldEqual, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	})
+}
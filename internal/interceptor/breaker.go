@@ -0,0 +1,208 @@
+package interceptor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/zeromicro/go-zero/core/collection"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/metric"
+	"github.com/zeromicro/go-zero/core/timex"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	breakerClosed = iota
+	breakerOpen
+	// 已放行一个探测请求，等待结果
+	breakerHalfOpen
+)
+
+var (
+	breakerRequests = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: "authorization",
+		Subsystem: "breaker",
+		Name:      "requests_total",
+		Help:      "upstream requests checked by the circuit breaker.",
+		Labels:    []string{"upstream", "result"},
+	})
+	breakerState = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: "authorization",
+		Subsystem: "breaker",
+		Name:      "state",
+		Help:      "circuit breaker state of the upstream, 0 closed, 1 open, 2 half open.",
+		Labels:    []string{"upstream"},
+	})
+)
+
+// Breaker 是上游服务客户端的熔断器
+//  连续失败达到阈值后熔断，熔断期间的请求直接返回 Unavailable，判定按降级处理；
+//  配置了 FactTTL 时优先返回最近一次相同请求的成功结果
+type Breaker struct {
+	name string
+	conf config.BreakerConf
+
+	lock     sync.Mutex
+	state    int
+	failures int
+	openedAt time.Duration
+
+	facts *collection.Cache
+}
+
+func MustNewBreaker(name string, c config.BreakerConf) *Breaker {
+	b := &Breaker{
+		name: name,
+		conf: c,
+	}
+	if c.FactTTL > 0 {
+		facts, err := collection.NewCache(c.FactTTL, collection.WithLimit(c.FactLimit),
+			collection.WithName(name+"-fact"))
+		logx.Must(err)
+		b.facts = facts
+	}
+	return b
+}
+
+func (b *Breaker) UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if b.conf.Failures <= 0 {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	if !b.allow() {
+		if b.fallback(method, req, reply) {
+			trace.SpanFromContext(ctx).SetAttributes(attribute.String(TraceCache, CacheHit))
+			breakerRequests.Inc(b.name, "fact")
+			return nil
+		}
+		if b.facts != nil {
+			trace.SpanFromContext(ctx).SetAttributes(attribute.String(TraceCache, CacheMiss))
+		}
+		breakerRequests.Inc(b.name, "rejected")
+		return status.Errorf(codes.Unavailable, "circuit breaker of %s is open", b.name)
+	}
+
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	b.mark(!isUpstreamFailure(err))
+	if err == nil {
+		b.remember(method, req, reply)
+	}
+	breakerRequests.Inc(b.name, "passed")
+	return err
+}
+
+// Open 判断是否处于熔断的冷却期中
+//  冷却结束后即使还没有请求探测也不视为熔断，否则健康检查摘除所有实例后没有请求能触发探测，
+//  服务无法自行恢复；探测失败时会重新熔断
+func (b *Breaker) Open() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.state == breakerOpen && timex.Since(b.openedAt) < b.conf.Cooldown
+}
+
+func (b *Breaker) allow() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if timex.Since(b.openedAt) < b.conf.Cooldown {
+			return false
+		}
+		b.setState(breakerHalfOpen)
+		return true
+	case breakerHalfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+// 记录请求结果，探测失败时重新熔断
+func (b *Breaker) mark(success bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if success {
+		b.failures = 0
+		if b.state != breakerClosed {
+			logx.Infof("circuit breaker of %s closed", b.name)
+			b.setState(breakerClosed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.conf.Failures {
+		if b.state == breakerClosed {
+			logx.Errorf("circuit breaker of %s opened after %d failures", b.name, b.failures)
+		}
+		b.openedAt = timex.Now()
+		b.setState(breakerOpen)
+	}
+}
+
+func (b *Breaker) setState(state int) {
+	b.state = state
+	breakerState.Set(float64(state), b.name)
+}
+
+func (b *Breaker) remember(method string, req, reply interface{}) {
+	if b.facts == nil {
+		return
+	}
+	key, ok := factKey(method, req)
+	if r, isProto := reply.(proto.Message); ok && isProto {
+		b.facts.Set(key, proto.Clone(r))
+	}
+}
+
+// 使用缓存的查询结果填充 reply
+func (b *Breaker) fallback(method string, req, reply interface{}) bool {
+	if b.facts == nil {
+		return false
+	}
+	key, ok := factKey(method, req)
+	if !ok {
+		return false
+	}
+	v, ok := b.facts.Get(key)
+	r, isProto := reply.(proto.Message)
+	if !ok || !isProto {
+		return false
+	}
+	proto.Reset(r)
+	proto.Merge(r, v.(proto.Message))
+	return true
+}
+
+func factKey(method string, req interface{}) (string, bool) {
+	m, ok := req.(proto.Message)
+	if !ok {
+		return "", false
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		return "", false
+	}
+	return method + "#" + string(data), true
+}
+
+// 只有上游服务本身不可用才计入失败，对象不存在等业务错误不计入
+func isUpstreamFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
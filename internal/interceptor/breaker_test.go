@@ -0,0 +1,106 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBreaker(t *testing.T) {
+	const method = "/authorization.authorization/allow"
+	var (
+		calls int
+		err   error
+	)
+	invoker := func(_ context.Context, _ string, _, reply interface{}, _ *grpc.ClientConn,
+		_ ...grpc.CallOption) error {
+		calls++
+		if err == nil {
+			reply.(*pb.AllowResp).Allow = true
+		}
+		return err
+	}
+	invoke := func(b *Breaker, userId string) (*pb.AllowResp, error) {
+		reply := new(pb.AllowResp)
+		e := b.UnaryClientInterceptor(context.Background(), method, &pb.AllowReq{UserId: userId}, reply, nil,
+			invoker)
+		return reply, e
+	}
+
+	Convey("连续失败后熔断，冷却后探测成功恢复", t, func() {
+		calls, err = 0, status.Error(codes.DeadlineExceeded, "timeout")
+		b := MustNewBreaker("system", config.BreakerConf{Failures: 2, Cooldown: 50 * time.Millisecond})
+		for i := 0; i < 2; i++ {
+			_, e := invoke(b, "a")
+			So(status.Code(e), ShouldEqual, codes.DeadlineExceeded)
+		}
+		_, e := invoke(b, "a")
+		So(status.Code(e), ShouldEqual, codes.Unavailable)
+		So(calls, ShouldEqual, 2)
+
+		time.Sleep(60 * time.Millisecond)
+		err = nil
+		resp, e := invoke(b, "a")
+		So(e, ShouldBeNil)
+		So(resp.Allow, ShouldBeTrue)
+		So(calls, ShouldEqual, 3)
+		_, e = invoke(b, "a")
+		So(e, ShouldBeNil)
+	})
+
+	Convey("冷却结束后没有请求也不再报告熔断", t, func() {
+		calls, err = 0, status.Error(codes.Unavailable, "unavailable")
+		b := MustNewBreaker("system", config.BreakerConf{Failures: 1, Cooldown: 50 * time.Millisecond})
+		_, _ = invoke(b, "a")
+		So(b.Open(), ShouldBeTrue)
+
+		time.Sleep(60 * time.Millisecond)
+		So(b.Open(), ShouldBeFalse)
+		So(calls, ShouldEqual, 1)
+
+		// 探测失败时重新熔断
+		_, _ = invoke(b, "a")
+		So(b.Open(), ShouldBeTrue)
+		So(calls, ShouldEqual, 2)
+	})
+
+	Convey("业务错误不计入失败", t, func() {
+		calls, err = 0, status.Error(10301, "not found")
+		b := MustNewBreaker("post", config.BreakerConf{Failures: 1, Cooldown: time.Minute})
+		for i := 0; i < 3; i++ {
+			_, e := invoke(b, "a")
+			So(status.Code(e), ShouldEqual, codes.Code(10301))
+		}
+		So(calls, ShouldEqual, 3)
+	})
+
+	Convey("熔断期间使用缓存的查询结果", t, func() {
+		calls, err = 0, nil
+		b := MustNewBreaker("moment", config.BreakerConf{
+			Failures:  1,
+			Cooldown:  time.Minute,
+			FactTTL:   time.Minute,
+			FactLimit: 10,
+		})
+		_, e := invoke(b, "a")
+		So(e, ShouldBeNil)
+
+		err = status.Error(codes.Unavailable, "unavailable")
+		_, e = invoke(b, "b")
+		So(status.Code(e), ShouldEqual, codes.Unavailable)
+
+		resp, e := invoke(b, "a")
+		So(e, ShouldBeNil)
+		So(resp.Allow, ShouldBeTrue)
+		_, e = invoke(b, "b")
+		So(status.Code(e), ShouldEqual, codes.Unavailable)
+		So(calls, ShouldEqual, 2)
+	})
+}
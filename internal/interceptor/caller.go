@@ -0,0 +1,155 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// 不需要认证的方法前缀，供 kubernetes 等探活使用
+const healthServicePrefix = "/grpc.health.v1.Health/"
+
+type callerKey struct{}
+
+// CallerFromContext 返回已认证的调用方服务名，未启用认证时为空
+func CallerFromContext(ctx context.Context) string {
+	caller, _ := ctx.Value(callerKey{}).(string)
+	return caller
+}
+
+// CallerAuth 校验调用方服务的身份
+type CallerAuth struct {
+	tokens  map[string]string
+	callers map[string]bool
+}
+
+func NewCallerAuth(c config.CallerAuthConf) *CallerAuth {
+	a := &CallerAuth{
+		tokens:  make(map[string]string),
+		callers: make(map[string]bool),
+	}
+	for _, caller := range c.Callers {
+		a.callers[caller.Name] = true
+		if caller.Token != "" {
+			a.tokens[caller.Token] = caller.Name
+		}
+	}
+	return a
+}
+
+func (a *CallerAuth) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if strings.HasPrefix(info.FullMethod, healthServicePrefix) {
+		return handler(ctx, req)
+	}
+
+	ctx, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *CallerAuth) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	if strings.HasPrefix(info.FullMethod, healthServicePrefix) {
+		return handler(srv, ss)
+	}
+
+	if _, err := a.authenticate(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// 依次尝试客户端证书和共享密钥，认证成功后将调用方记录到上下文和日志字段中
+func (a *CallerAuth) authenticate(ctx context.Context) (context.Context, error) {
+	caller := a.callerFromCert(ctx)
+	if caller == "" {
+		caller = a.callerFromToken(ctx)
+	}
+	if caller == "" {
+		logx.WithContext(ctx).Infof("[CallerAuth] rejected unauthenticated caller from %s", peerAddr(ctx))
+		return ctx, status.Error(codes.Unauthenticated, "unknown caller")
+	}
+
+	ctx = context.WithValue(ctx, callerKey{}, caller)
+	return logx.ContextWithFields(ctx, logx.Field("caller", caller)), nil
+}
+
+func (a *CallerAuth) callerFromCert(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 {
+		return ""
+	}
+
+	name := info.State.VerifiedChains[0][0].Subject.CommonName
+	if !a.callers[name] {
+		return ""
+	}
+	return name
+}
+
+func (a *CallerAuth) callerFromToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(MetadataCallerToken)
+	if len(values) == 0 {
+		return ""
+	}
+
+	for token, name := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(values[0])) == 1 {
+			return name
+		}
+	}
+	return ""
+}
+
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+	return "unknown"
+}
+
+// MustNewServerCreds 返回校验客户端证书的 TLS 凭证
+//  客户端证书是可选的，没有证书的调用方仍可使用共享密钥认证
+func MustNewServerCreds(c config.TLSConf) credentials.TransportCredentials {
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	logx.Must(err)
+
+	ca, err := os.ReadFile(c.CAFile)
+	logx.Must(err)
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		logx.Must(fmt.Errorf("no certificates found in %s", c.CAFile))
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+		MinVersion:   tls.VersionTLS12,
+	})
+}
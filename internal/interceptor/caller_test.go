@@ -0,0 +1,52 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestCallerAuth(t *testing.T) {
+	auth := NewCallerAuth(config.CallerAuthConf{
+		Callers: []config.CallerConf{
+			{Name: "meowchat-core-api", Token: "secret"},
+			{Name: "meowchat-post-rpc"},
+		},
+	})
+	info := &grpc.UnaryServerInfo{FullMethod: "/authorization.authorization/allow"}
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return CallerFromContext(ctx), nil
+	}
+
+	Convey("允许持有密钥的调用方", t, func() {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataCallerToken, "secret"))
+		caller, err := auth.UnaryInterceptor(ctx, nil, info, handler)
+		So(err, ShouldBeNil)
+		So(caller, ShouldEqual, "meowchat-core-api")
+	})
+
+	Convey("拒绝错误的密钥", t, func() {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataCallerToken, "wrong"))
+		_, err := auth.UnaryInterceptor(ctx, nil, info, handler)
+		So(status.Code(err), ShouldEqual, codes.Unauthenticated)
+	})
+
+	Convey("拒绝没有凭证的调用方", t, func() {
+		_, err := auth.UnaryInterceptor(context.Background(), nil, info, handler)
+		So(status.Code(err), ShouldEqual, codes.Unauthenticated)
+	})
+
+	Convey("健康检查不需要认证", t, func() {
+		_, err := auth.UnaryInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{
+			FullMethod: "/grpc.health.v1.Health/Check",
+		}, handler)
+		So(err, ShouldBeNil)
+	})
+}
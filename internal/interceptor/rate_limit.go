@@ -0,0 +1,144 @@
+package interceptor
+
+import (
+	"context"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	"github.com/zeromicro/go-zero/core/collection"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/metric"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	limitKindCaller = "caller"
+	limitKindUser   = "user"
+	// 未启用调用方认证时共用的桶
+	unknownCaller = "unknown"
+	// 长时间不活跃的用户桶会被回收，回收后桶是满的，所以过期时间不影响限流效果
+	userLimiterExpire = 10 * time.Minute
+	userLimiterLimit  = 100000
+)
+
+var (
+	errCallerRateLimited = status.Error(codes.ResourceExhausted, "caller rate limit exceeded")
+	errUserRateLimited   = status.Error(codes.ResourceExhausted, "user rate limit exceeded")
+
+	rateLimitRequests = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: "authorization",
+		Subsystem: "ratelimit",
+		Name:      "requests_total",
+		Help:      "authorization requests checked by the rate limiter.",
+		Labels:    []string{"kind", "result"},
+	})
+	// 用户数量不可控，只按调用方导出剩余令牌
+	rateLimitTokens = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: "authorization",
+		Subsystem: "ratelimit",
+		Name:      "caller_tokens",
+		Help:      "tokens left in the caller rate limit bucket.",
+		Labels:    []string{"caller"},
+	})
+)
+
+// RateLimit 按调用方和 userId 限制 Allow 的请求速率
+type RateLimit struct {
+	callerDefault config.LimitConf
+	callerConfs   map[string]config.LimitConf
+	// 调用方数量有限，创建后不回收
+	callers sync.Map
+	user    config.LimitConf
+	users   *collection.Cache
+}
+
+func MustNewRateLimit(c config.RateLimitConf) *RateLimit {
+	users, err := collection.NewCache(userLimiterExpire, collection.WithLimit(userLimiterLimit),
+		collection.WithName("user-rate-limit"))
+	logx.Must(err)
+
+	l := &RateLimit{
+		callerDefault: c.Caller,
+		callerConfs:   make(map[string]config.LimitConf, len(c.Callers)),
+		user:          c.User,
+		users:         users,
+	}
+	for _, caller := range c.Callers {
+		l.callerConfs[caller.Name] = caller.LimitConf
+	}
+	return l
+}
+
+func (l *RateLimit) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if strings.HasPrefix(info.FullMethod, healthServicePrefix) {
+		return handler(ctx, req)
+	}
+
+	if !l.allowCaller(CallerFromContext(ctx)) {
+		return nil, errCallerRateLimited
+	}
+	if in, ok := req.(*pb.AllowReq); ok && !l.allowUser(in.UserId) {
+		return nil, errUserRateLimited
+	}
+	return handler(ctx, req)
+}
+
+func (l *RateLimit) allowCaller(caller string) bool {
+	if caller == "" {
+		caller = unknownCaller
+	}
+	c, ok := l.callerConfs[caller]
+	if !ok {
+		c = l.callerDefault
+	}
+	if c.Rate <= 0 {
+		return true
+	}
+
+	v, ok := l.callers.Load(caller)
+	if !ok {
+		v, _ = l.callers.LoadOrStore(caller, newLimiter(c))
+	}
+	limiter := v.(*rate.Limiter)
+	allow := limiter.Allow()
+	rateLimitTokens.Set(limiter.Tokens(), caller)
+	report(limitKindCaller, allow)
+	return allow
+}
+
+func (l *RateLimit) allowUser(userId string) bool {
+	if l.user.Rate <= 0 || userId == "" {
+		return true
+	}
+
+	v, _ := l.users.Take(userId, func() (interface{}, error) {
+		return newLimiter(l.user), nil
+	})
+	allow := v.(*rate.Limiter).Allow()
+	report(limitKindUser, allow)
+	return allow
+}
+
+func newLimiter(c config.LimitConf) *rate.Limiter {
+	burst := c.Burst
+	if burst <= 0 {
+		burst = int(math.Ceil(c.Rate))
+	}
+	return rate.NewLimiter(rate.Limit(c.Rate), burst)
+}
+
+func report(kind string, allow bool) {
+	if allow {
+		rateLimitRequests.Inc(kind, "allowed")
+	} else {
+		rateLimitRequests.Inc(kind, "limited")
+	}
+}
@@ -0,0 +1,58 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateLimit(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/authorization.authorization/allow"}
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	ctx := context.WithValue(context.Background(), callerKey{}, "meowchat-core-api")
+
+	Convey("超过调用方限额时返回 ResourceExhausted", t, func() {
+		l := MustNewRateLimit(config.RateLimitConf{
+			Caller: config.LimitConf{Rate: 0.001, Burst: 1},
+		})
+		_, err := l.UnaryInterceptor(ctx, &pb.AllowReq{}, info, handler)
+		So(err, ShouldBeNil)
+		_, err = l.UnaryInterceptor(ctx, &pb.AllowReq{}, info, handler)
+		So(status.Code(err), ShouldEqual, codes.ResourceExhausted)
+	})
+
+	Convey("单独配置的调用方使用自己的限额", t, func() {
+		l := MustNewRateLimit(config.RateLimitConf{
+			Caller: config.LimitConf{Rate: 0.001, Burst: 1},
+			Callers: []config.CallerLimitConf{
+				{Name: "meowchat-core-api", LimitConf: config.LimitConf{Rate: 0}},
+			},
+		})
+		for i := 0; i < 3; i++ {
+			_, err := l.UnaryInterceptor(ctx, &pb.AllowReq{}, info, handler)
+			So(err, ShouldBeNil)
+		}
+	})
+
+	Convey("每个用户单独计数", t, func() {
+		l := MustNewRateLimit(config.RateLimitConf{
+			User: config.LimitConf{Rate: 0.001, Burst: 1},
+		})
+		_, err := l.UnaryInterceptor(ctx, &pb.AllowReq{UserId: "a"}, info, handler)
+		So(err, ShouldBeNil)
+		_, err = l.UnaryInterceptor(ctx, &pb.AllowReq{UserId: "b"}, info, handler)
+		So(err, ShouldBeNil)
+		_, err = l.UnaryInterceptor(ctx, &pb.AllowReq{UserId: "a"}, info, handler)
+		So(status.Code(err), ShouldEqual, codes.ResourceExhausted)
+		_, err = l.UnaryInterceptor(ctx, &pb.AllowReq{}, info, handler)
+		So(err, ShouldBeNil)
+	})
+}
@@ -0,0 +1,240 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v4"
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errNoUserToken      = status.Error(codes.Unauthenticated, "missing user token")
+	errInvalidUserToken = status.Error(codes.Unauthenticated, "invalid user token")
+	errSubjectMismatch  = status.Error(codes.PermissionDenied, "userId does not match the user token")
+)
+
+// UserToken 使用终端用户的 JWT 确定请求中的用户
+type UserToken struct {
+	required bool
+	secret   []byte
+	// JWKS 中的公钥，key 为 kid
+	keys map[string]interface{}
+}
+
+func MustNewUserToken(c config.UserTokenConf) *UserToken {
+	t := &UserToken{
+		required: c.Mode == config.UserTokenRequired,
+		secret:   []byte(c.Secret),
+	}
+	if c.JwksFile != "" {
+		data, err := os.ReadFile(c.JwksFile)
+		logx.Must(err)
+		t.keys, err = parseJwks(data)
+		logx.Must(err)
+	}
+	return t
+}
+
+func (t *UserToken) UnaryInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	userId := userIdOf(req)
+	if userId == nil {
+		return handler(ctx, req)
+	}
+
+	token := userTokenFromContext(ctx)
+	if token == "" {
+		if t.required {
+			return nil, errNoUserToken
+		}
+		return handler(ctx, req)
+	}
+
+	subject, err := t.verify(token)
+	if err != nil {
+		logx.WithContext(ctx).Infof("[UserToken] invalid user token, err: %v", err)
+		return nil, errInvalidUserToken
+	}
+
+	// 未传入 userId 时以令牌中的用户为准，传入时必须一致
+	switch *userId {
+	case "":
+		*userId = subject
+	case subject:
+	default:
+		return nil, errSubjectMismatch
+	}
+	return handler(ctx, req)
+}
+
+// 返回请求中代表终端用户的字段，其他请求返回nil
+func userIdOf(req interface{}) *string {
+	switch in := req.(type) {
+	case *pb.AllowReq:
+		return &in.UserId
+	case *pb.BlockReq:
+		return &in.UserId
+	case *pb.UnblockReq:
+		return &in.UserId
+	case *pb.ListBlockReq:
+		return &in.UserId
+	case *pb.SetProfileVisibilityReq:
+		return &in.UserId
+	case *pb.LockReq:
+		return &in.UserId
+	case *pb.UnlockReq:
+		return &in.UserId
+	case *pb.GrantReq:
+		return &in.UserId
+	case *pb.RevokeReq:
+		return &in.UserId
+	case *pb.SetCommunityVisibilityReq:
+		return &in.UserId
+	case *pb.AddMemberReq:
+		return &in.UserId
+	case *pb.RemoveMemberReq:
+		return &in.UserId
+	case *pb.ReportReq:
+		return &in.UserId
+	case *pb.CloseReportReq:
+		return &in.UserId
+	case *pb.FollowReq:
+		return &in.UserId
+	case *pb.UnfollowReq:
+		return &in.UserId
+	}
+	return nil
+}
+
+// 校验令牌并返回其中的 sub
+func (t *UserToken) verify(token string) (string, error) {
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(token, &claims, t.keyFunc)
+	if err != nil {
+		return "", err
+	}
+	if claims.Subject == "" {
+		return "", errors.New("token has no subject")
+	}
+	return claims.Subject, nil
+}
+
+func (t *UserToken) keyFunc(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if len(t.secret) == 0 {
+			return nil, errors.New("hmac secret is not configured")
+		}
+		return t.secret, nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS, *jwt.SigningMethodECDSA:
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := t.keys[kid]; ok {
+			return key, nil
+		}
+		// 只有一个公钥时允许令牌不带 kid
+		if kid == "" && len(t.keys) == 1 {
+			for _, key := range t.keys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+}
+
+func userTokenFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(MetadataUserToken); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// 解析 JWKS 中的 RSA 和 EC 公钥
+func parseJwks(data []byte) (map[string]interface{}, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, k := range set.Keys {
+		switch k.Kty {
+		case "RSA":
+			n, err := decodeBigInt(k.N)
+			if err != nil {
+				return nil, err
+			}
+			e, err := decodeBigInt(k.E)
+			if err != nil {
+				return nil, err
+			}
+			keys[k.Kid] = &rsa.PublicKey{N: n, E: int(e.Int64())}
+		case "EC":
+			var curve elliptic.Curve
+			switch k.Crv {
+			case "P-256":
+				curve = elliptic.P256()
+			case "P-384":
+				curve = elliptic.P384()
+			case "P-521":
+				curve = elliptic.P521()
+			default:
+				return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+			}
+			x, err := decodeBigInt(k.X)
+			if err != nil {
+				return nil, err
+			}
+			y, err := decodeBigInt(k.Y)
+			if err != nil {
+				return nil, err
+			}
+			keys[k.Kid] = &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+		default:
+			return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+		}
+	}
+	return keys, nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,124 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	. "github.com/smartystreets/goconvey/convey"
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUserToken(t *testing.T) {
+	secret := "secret"
+	sign := func(subject string, key string) string {
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+			Subject:   subject,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}).SignedString([]byte(key))
+		return token
+	}
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataUserToken, token))
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/authorization.authorization/allow"}
+	handler := func(_ context.Context, req interface{}) (interface{}, error) {
+		return req.(*pb.AllowReq).UserId, nil
+	}
+
+	Convey("optional 模式", t, func() {
+		ut := MustNewUserToken(config.UserTokenConf{Mode: config.UserTokenOptional, Secret: secret})
+
+		Convey("未传入 userId 时使用令牌中的用户", func() {
+			userId, err := ut.UnaryInterceptor(withToken(sign("user", secret)), &pb.AllowReq{}, info, handler)
+			So(err, ShouldBeNil)
+			So(userId, ShouldEqual, "user")
+		})
+
+		Convey("userId 与令牌一致", func() {
+			userId, err := ut.UnaryInterceptor(withToken(sign("user", secret)), &pb.AllowReq{UserId: "user"}, info, handler)
+			So(err, ShouldBeNil)
+			So(userId, ShouldEqual, "user")
+		})
+
+		Convey("拒绝与令牌不一致的 userId", func() {
+			_, err := ut.UnaryInterceptor(withToken(sign("user", secret)), &pb.AllowReq{UserId: "admin"}, info, handler)
+			So(status.Code(err), ShouldEqual, codes.PermissionDenied)
+		})
+
+		Convey("拒绝签名错误的令牌", func() {
+			_, err := ut.UnaryInterceptor(withToken(sign("user", "wrong")), &pb.AllowReq{}, info, handler)
+			So(status.Code(err), ShouldEqual, codes.Unauthenticated)
+		})
+
+		Convey("未携带令牌时信任 userId", func() {
+			userId, err := ut.UnaryInterceptor(context.Background(), &pb.AllowReq{UserId: "user"}, info, handler)
+			So(err, ShouldBeNil)
+			So(userId, ShouldEqual, "user")
+		})
+	})
+
+	Convey("required 模式拒绝未携带令牌的请求", t, func() {
+		ut := MustNewUserToken(config.UserTokenConf{Mode: config.UserTokenRequired, Secret: secret})
+		_, err := ut.UnaryInterceptor(context.Background(), &pb.AllowReq{UserId: "user"}, info, handler)
+		So(status.Code(err), ShouldEqual, codes.Unauthenticated)
+	})
+
+	Convey("required 模式下授予和撤销角色也需要令牌", t, func() {
+		ut := MustNewUserToken(config.UserTokenConf{Mode: config.UserTokenRequired, Secret: secret})
+		role := &pb.Role{Type: "superAdmin"}
+		for _, req := range []interface{}{
+			&pb.GrantReq{UserId: "super", GranteeId: "user", Role: role},
+			&pb.RevokeReq{UserId: "super", GranteeId: "user", Role: role},
+		} {
+			_, err := ut.UnaryInterceptor(context.Background(), req, info, handler)
+			So(status.Code(err), ShouldEqual, codes.Unauthenticated)
+		}
+
+		// 令牌中的用户与操作的用户不一致
+		_, err := ut.UnaryInterceptor(withToken(sign("user", secret)),
+			&pb.GrantReq{UserId: "super", GranteeId: "user", Role: role}, info, handler)
+		So(status.Code(err), ShouldEqual, codes.PermissionDenied)
+	})
+
+	Convey("required 模式下管理私有社区也需要令牌", t, func() {
+		ut := MustNewUserToken(config.UserTokenConf{Mode: config.UserTokenRequired, Secret: secret})
+		for _, req := range []interface{}{
+			&pb.SetCommunityVisibilityReq{UserId: "admin", CommunityId: "community"},
+			&pb.AddMemberReq{UserId: "admin", CommunityId: "community", MemberId: "user"},
+			&pb.RemoveMemberReq{UserId: "admin", CommunityId: "community", MemberId: "user"},
+		} {
+			_, err := ut.UnaryInterceptor(context.Background(), req, info, handler)
+			So(status.Code(err), ShouldEqual, codes.Unauthenticated)
+		}
+	})
+
+	Convey("required 模式下举报和处理举报也需要令牌", t, func() {
+		ut := MustNewUserToken(config.UserTokenConf{Mode: config.UserTokenRequired, Secret: secret})
+		for _, req := range []interface{}{
+			&pb.ReportReq{UserId: "user", Object: "post", ObjectId: "post"},
+			&pb.CloseReportReq{UserId: "admin", Object: "post", ObjectId: "post"},
+		} {
+			_, err := ut.UnaryInterceptor(context.Background(), req, info, handler)
+			So(status.Code(err), ShouldEqual, codes.Unauthenticated)
+		}
+	})
+
+	Convey("required 模式下关注和取消关注也需要令牌", t, func() {
+		ut := MustNewUserToken(config.UserTokenConf{Mode: config.UserTokenRequired, Secret: secret})
+		for _, req := range []interface{}{
+			&pb.FollowReq{UserId: "user", FollowedUserId: "another"},
+			&pb.UnfollowReq{UserId: "user", FollowedUserId: "another"},
+		} {
+			_, err := ut.UnaryInterceptor(context.Background(), req, info, handler)
+			So(status.Code(err), ShouldEqual, codes.Unauthenticated)
+		}
+	})
+}
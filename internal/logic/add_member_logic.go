@@ -0,0 +1,42 @@
+package logic
+
+import (
+	"context"
+
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type AddMemberLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewAddMemberLogic(ctx context.Context, svcCtx *svc.ServiceContext) *AddMemberLogic {
+	return &AddMemberLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *AddMemberLogic) AddMember(in *pb.AddMemberReq) (*pb.AddMemberResp, error) {
+	if in.UserId == "" || in.CommunityId == "" || in.MemberId == "" {
+		return nil, errorx.ErrInvalidArgs
+	}
+	if !NewAllowLogic(l.ctx, l.svcCtx).allowModerate(in.UserId, ObjectCommunity, in.CommunityId) {
+		return nil, errorx.ErrPermissionDenied
+	}
+
+	// 已经是成员时不会插入
+	err := l.svcCtx.MemberModel.UpsertByUserIdAndCommunityId(l.ctx, in.MemberId, in.CommunityId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AddMemberResp{}, nil
+}
@@ -0,0 +1,68 @@
+package logic
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/logic/mock"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	pb2 "github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	. "github.com/xh-polaris/meowchat-system-rpc/constant"
+	"github.com/xh-polaris/meowchat-system-rpc/pb"
+)
+
+func TestMemberLogic(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
+	mockMemberModel := mock.NewMockMemberModel(ctrl)
+	svcCtx := &svc.ServiceContext{
+		SystemRPC:   mockSystemRpc,
+		MemberModel: mockMemberModel,
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+	add := NewAddMemberLogic(context.Background(), svcCtx)
+	remove := NewRemoveMemberLogic(context.Background(), svcCtx)
+
+	expectRoles := func(roles ...*pb.Role) {
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{Roles: roles}, nil)
+	}
+	communityAdmin := &pb.Role{Type: RoleCommunityAdmin, CommunityId: "CommunityId"}
+	superAdmin := &pb.Role{Type: RoleSuperAdmin}
+
+	Convey("参数不合法时报错", t, func() {
+		_, err := add.AddMember(&pb2.AddMemberReq{UserId: "AdminId", CommunityId: "CommunityId"})
+		So(err, ShouldEqual, errorx.ErrInvalidArgs)
+		_, err = remove.RemoveMember(&pb2.RemoveMemberReq{CommunityId: "CommunityId", MemberId: "MemberId"})
+		So(err, ShouldEqual, errorx.ErrInvalidArgs)
+	})
+
+	Convey("普通用户不能管理成员", t, func() {
+		expectRoles()
+		_, err := add.AddMember(&pb2.AddMemberReq{UserId: "UserId", CommunityId: "CommunityId", MemberId: "UserId"})
+		So(err, ShouldEqual, errorx.ErrPermissionDenied)
+
+		expectRoles()
+		_, err = remove.RemoveMember(&pb2.RemoveMemberReq{UserId: "UserId", CommunityId: "CommunityId", MemberId: "MemberId"})
+		So(err, ShouldEqual, errorx.ErrPermissionDenied)
+	})
+
+	Convey("社区管理员添加成员", t, func() {
+		expectRoles(communityAdmin)
+		mockMemberModel.EXPECT().UpsertByUserIdAndCommunityId(Any(), "MemberId", "CommunityId").Return(nil)
+		_, err := add.AddMember(&pb2.AddMemberReq{UserId: "AdminId", CommunityId: "CommunityId", MemberId: "MemberId"})
+		So(err, ShouldBeNil)
+	})
+
+	Convey("超级管理员移除成员", t, func() {
+		// 删除所有相同的成员记录
+		expectRoles(superAdmin)
+		mockMemberModel.EXPECT().DeleteByUserIdAndCommunityId(Any(), "MemberId", "CommunityId").Return(int64(2), nil)
+		_, err := remove.RemoveMember(&pb2.RemoveMemberReq{UserId: "SuperAdminId", CommunityId: "CommunityId", MemberId: "MemberId"})
+		So(err, ShouldBeNil)
+	})
+}
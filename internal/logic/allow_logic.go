@@ -3,16 +3,20 @@ package logic
 import (
 	"context"
 	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
 	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
 	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
 
 	"github.com/zeromicro/go-zero/core/logx"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type AllowLogic struct {
 	ctx    context.Context
 	svcCtx *svc.ServiceContext
 	logx.Logger
+	// 判定过程中上游服务或存储不可用
+	degraded bool
 }
 
 func NewAllowLogic(ctx context.Context, svcCtx *svc.ServiceContext) *AllowLogic {
@@ -23,19 +27,74 @@ func NewAllowLogic(ctx context.Context, svcCtx *svc.ServiceContext) *AllowLogic
 	}
 }
 
-var policies = map[string]func(*AllowLogic, *pb.AllowReq) bool{
-	ObjectCommunity: (*AllowLogic).allowCommunity,
-	ObjectNews:      (*AllowLogic).allowNews,
-	ObjectNotice:    (*AllowLogic).allowNotice,
-	ObjectPost:      (*AllowLogic).allowPost,
-	ObjectCat:       (*AllowLogic).allowCat,
-	ObjectMoment:    (*AllowLogic).allowMoment,
-	ObjectComment:   (*AllowLogic).allowComment,
+// 对象类型的内置策略
+//  评论的策略按从属对象的类型查找策略，直接初始化会形成初始化循环，在 init 中初始化
+var policies map[string]func(*AllowLogic, *pb.AllowReq) bool
+
+func init() {
+	policies = map[string]func(*AllowLogic, *pb.AllowReq) bool{
+		ObjectCommunity: (*AllowLogic).allowCommunity,
+		ObjectNews:      (*AllowLogic).allowNews,
+		ObjectNotice:    (*AllowLogic).allowNotice,
+		ObjectPost:      (*AllowLogic).allowPost,
+		ObjectCat:       (*AllowLogic).allowCat,
+		ObjectMoment:    (*AllowLogic).allowMoment,
+		ObjectComment:   (*AllowLogic).allowComment,
+		ObjectUser:      (*AllowLogic).allowUser,
+		ObjectAdmin:     (*AllowLogic).allowAdmin,
+		ObjectRole:      (*AllowLogic).allowRole,
+		ObjectLike:      (*AllowLogic).allowLike,
+		ObjectFollow:    (*AllowLogic).allowFollow,
+		ObjectReport:    (*AllowLogic).allowReport,
+	}
 }
 
 func (l *AllowLogic) Allow(in *pb.AllowReq) (*pb.AllowResp, error) {
-	p := policies[in.Object]
+	span, end := l.startSpan("allow",
+		attribute.String(TraceObject, in.Object),
+		attribute.String(TraceAction, in.Action),
+	)
+	defer end()
+
+	allow := l.evaluate(l.svcCtx.Policy, in)
+	if l.degraded {
+		allow = l.degrade(in)
+	} else {
+		l.remember(in, allow)
+	}
+	span.SetAttributes(attribute.Bool(TraceDecision, allow), attribute.Bool(TraceDegraded, l.degraded))
+	l.audit(in, allow)
+	if !l.degraded {
+		l.evaluateShadow(in, allow)
+	}
 	return &pb.AllowResp{
-		Allow: p != nil && p(l, in),
+		Allow:    allow,
+		Degraded: l.degraded,
 	}, nil
 }
+
+// 使用策略集判定请求，匿名用户只按配置判定，未配置其他引擎的对象使用内置策略
+//  没有内置策略但注册了解析器的对象使用通用策略
+//  私有社区、内容状态和用户资料可见性的读限制，以及发布者的修改期限和锁定限制对所有引擎生效
+func (l *AllowLogic) evaluate(p *svc.Policy, in *pb.AllowReq) bool {
+	var allow bool
+	switch {
+	case in.UserId == AnonymousUserId:
+		allow = l.allowAnonymous(in)
+	case p.Uses(config.EngineRego, in.Object):
+		allow = l.allowRego(p, in)
+	case p.Uses(config.EngineCasbin, in.Object):
+		allow = l.allowCasbin(p, in)
+	default:
+		policy := policies[in.Object]
+		if _, ok := l.svcCtx.Resolvers.Get(in.Object); policy == nil && ok {
+			policy = (*AllowLogic).allowResource
+		}
+		allow = policy != nil && l.tracePolicy(in.Object, policy, in)
+	}
+
+	if allow && in.Action == ActionRead {
+		return l.allowReadCommunity(in) && l.allowReadStatus(in) && l.allowReadProfile(in)
+	}
+	return allow && l.allowOwnerWrite(in)
+}
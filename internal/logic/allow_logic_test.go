@@ -2,19 +2,33 @@ package logic
 
 import (
 	"context"
+	"errors"
 	. "github.com/golang/mock/gomock"
 	. "github.com/smartystreets/goconvey/convey"
 	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
 	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
 	"github.com/xh-polaris/meowchat-authorization-rpc/internal/logic/mock"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/resolver"
 	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
 	pb2 "github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	pb6 "github.com/xh-polaris/meowchat-collection-rpc/pb"
 	pb4 "github.com/xh-polaris/meowchat-comment-rpc/pb"
 	pb5 "github.com/xh-polaris/meowchat-moment-rpc/pb"
 	pb3 "github.com/xh-polaris/meowchat-post-rpc/pb"
 	. "github.com/xh-polaris/meowchat-system-rpc/constant"
 	"github.com/xh-polaris/meowchat-system-rpc/pb"
+	"github.com/zeromicro/go-zero/core/collection"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync"
 	"testing"
+	"time"
 	_ "unsafe"
 )
 
@@ -36,6 +50,7 @@ func TestAllowLogic_Allow_Community(t *testing.T) {
 		CommentRPC:    mockCommentRpc,
 		PostRPC:       mockPostRpc,
 	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
 	l := NewAllowLogic(context.Background(), svcCtx)
 
 	Convey("允许读", t, func() {
@@ -55,6 +70,7 @@ func TestAllowLogic_Allow_Community(t *testing.T) {
 			},
 		}, nil)
 		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId: "UserId",
 			Object: ObjectCommunity,
 			Action: ActionWrite,
 		})
@@ -70,6 +86,7 @@ func TestAllowLogic_Allow_Community(t *testing.T) {
 			},
 		}, nil)
 		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId: "UserId",
 			Object: ObjectCommunity,
 			Action: ActionWrite,
 		})
@@ -86,6 +103,7 @@ func TestAllowLogic_Allow_Community(t *testing.T) {
 			},
 		}, nil)
 		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
 			Object:   ObjectCommunity,
 			ObjectId: "TestCommId",
 			Action:   ActionWrite,
@@ -108,6 +126,7 @@ func TestAllowLogic_Allow_Community(t *testing.T) {
 			},
 		}, nil)
 		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
 			Object:   ObjectCommunity,
 			ObjectId: "TestCommId2",
 			Action:   ActionWrite,
@@ -131,6 +150,7 @@ func TestAllowLogic_Allow_Community(t *testing.T) {
 			},
 		}, nil)
 		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
 			Object:   ObjectCommunity,
 			ObjectId: "Child",
 			Action:   ActionWrite,
@@ -164,6 +184,7 @@ func TestAllowLogic_Allow_Community(t *testing.T) {
 			},
 		}, nil)
 		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
 			Object:   ObjectCommunity,
 			ObjectId: "Child",
 			Action:   ActionWrite,
@@ -190,6 +211,7 @@ func TestAllowLogic_Allow_Notice(t *testing.T) {
 		CommentRPC:    mockCommentRpc,
 		PostRPC:       mockPostRpc,
 	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
 	l := NewAllowLogic(context.Background(), svcCtx)
 
 	Convey("允许读", t, func() {
@@ -209,6 +231,7 @@ func TestAllowLogic_Allow_Notice(t *testing.T) {
 			},
 		}, nil)
 		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId: "UserId",
 			Object: ObjectCommunity,
 			Action: ActionWrite,
 		})
@@ -225,6 +248,7 @@ func TestAllowLogic_Allow_Notice(t *testing.T) {
 			},
 		}, nil)
 		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId: "UserId",
 			Object: ObjectNotice,
 			Action: ActionWrite,
 		})
@@ -247,6 +271,7 @@ func TestAllowLogic_Allow_Notice(t *testing.T) {
 			},
 		}, nil)
 		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
 			Object:   ObjectNotice,
 			ObjectId: "NoticeId",
 			Action:   ActionWrite,
@@ -275,6 +300,7 @@ func TestAllowLogic_Allow_Notice(t *testing.T) {
 			},
 		}, nil)
 		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
 			Object:   ObjectNotice,
 			ObjectId: "NoticeId",
 			Action:   ActionWrite,
@@ -304,6 +330,7 @@ func TestAllowLogic_Allow_Notice(t *testing.T) {
 			},
 		}, nil)
 		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
 			Object:   ObjectNotice,
 			ObjectId: "NoticeId",
 			Action:   ActionWrite,
@@ -343,6 +370,7 @@ func TestAllowLogic_Allow_Notice(t *testing.T) {
 			},
 		}, nil)
 		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
 			Object:   ObjectNotice,
 			ObjectId: "NoticeId",
 			Action:   ActionWrite,
@@ -360,6 +388,7 @@ func TestAllowLogic_Allow_Post(t *testing.T) {
 	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
 	mockCommentRpc := mock.NewMockCommentRpc(ctrl)
 	mockPostRpc := mock.NewMockPostRpc(ctrl)
+	mockLockModel := mock.NewMockLockModel(ctrl)
 
 	svcCtx := &svc.ServiceContext{
 		Config:        config.Config{},
@@ -368,7 +397,9 @@ func TestAllowLogic_Allow_Post(t *testing.T) {
 		SystemRPC:     mockSystemRpc,
 		CommentRPC:    mockCommentRpc,
 		PostRPC:       mockPostRpc,
+		LockModel:     mockLockModel,
 	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
 	l := NewAllowLogic(context.Background(), svcCtx)
 
 	Convey("允许读", t, func() {
@@ -405,6 +436,7 @@ func TestAllowLogic_Allow_Post(t *testing.T) {
 			},
 		}, nil)
 		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId: "UserId",
 			Object: ObjectPost,
 			Action: ActionWrite,
 		})
@@ -412,13 +444,14 @@ func TestAllowLogic_Allow_Post(t *testing.T) {
 	})
 
 	Convey("允许帖子发布者", t, func() {
-		mockPostRpc.EXPECT().RetrievePost(Any(), Any()).Return(&pb3.RetrievePostResp{
+		mockPostRpc.EXPECT().RetrievePost(Any(), Any()).Times(2).Return(&pb3.RetrievePostResp{
 			Post: &pb3.Post{
 				Id:     "PostId",
 				UserId: "PostUserId",
 			},
 		}, nil)
 		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any(), Any()).Return(&pb.RetrieveUserRoleResp{}, nil)
+		mockLockModel.EXPECT().FindOneByObjectAndObjectId(Any(), ObjectPost, "PostId").Return(nil, model.ErrNotFound)
 		allow, _ := l.Allow(&pb2.AllowReq{
 			UserId:   "PostUserId",
 			Object:   ObjectPost,
@@ -428,6 +461,73 @@ func TestAllowLogic_Allow_Post(t *testing.T) {
 		So(allow.Allow, ShouldBeTrue)
 	})
 
+	Convey("不允许发布者修改已锁定的帖子", t, func() {
+		mockPostRpc.EXPECT().RetrievePost(Any(), Any()).Times(3).Return(&pb3.RetrievePostResp{
+			Post: &pb3.Post{
+				Id:     "PostId",
+				UserId: "PostUserId",
+			},
+		}, nil)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any(), Any()).Times(2).Return(&pb.RetrieveUserRoleResp{}, nil)
+		mockLockModel.EXPECT().FindOneByObjectAndObjectId(Any(), ObjectPost, "PostId").Return(&model.Lock{
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "PostUserId",
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
+	Convey("不允许发布者超过修改期限后修改帖子", t, func() {
+		svcCtx.Config.EditWindows = []config.EditWindowConf{
+			{Object: ObjectPost, Duration: 24 * time.Hour},
+		}
+		defer func() { svcCtx.Config.EditWindows = nil }()
+
+		mockPostRpc.EXPECT().RetrievePost(Any(), Any()).Times(3).Return(&pb3.RetrievePostResp{
+			Post: &pb3.Post{
+				Id:       "PostId",
+				UserId:   "PostUserId",
+				CreateAt: time.Now().Add(-48 * time.Hour).Unix(),
+			},
+		}, nil)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any(), Any()).Times(2).Return(&pb.RetrieveUserRoleResp{}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "PostUserId",
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
+	Convey("允许发布者在修改期限内修改帖子", t, func() {
+		svcCtx.Config.EditWindows = []config.EditWindowConf{
+			{Object: ObjectPost, Duration: 24 * time.Hour},
+		}
+		defer func() { svcCtx.Config.EditWindows = nil }()
+
+		mockPostRpc.EXPECT().RetrievePost(Any(), Any()).Times(2).Return(&pb3.RetrievePostResp{
+			Post: &pb3.Post{
+				Id:       "PostId",
+				UserId:   "PostUserId",
+				CreateAt: time.Now().Add(-time.Hour).Unix(),
+			},
+		}, nil)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any(), Any()).Return(&pb.RetrieveUserRoleResp{}, nil)
+		mockLockModel.EXPECT().FindOneByObjectAndObjectId(Any(), ObjectPost, "PostId").Return(nil, model.ErrNotFound)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "PostUserId",
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
 }
 
 func TestAllowLogic_Allow_Comment(t *testing.T) {
@@ -439,6 +539,7 @@ func TestAllowLogic_Allow_Comment(t *testing.T) {
 	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
 	mockCommentRpc := mock.NewMockCommentRpc(ctrl)
 	mockPostRpc := mock.NewMockPostRpc(ctrl)
+	mockLockModel := mock.NewMockLockModel(ctrl)
 
 	svcCtx := &svc.ServiceContext{
 		Config:        config.Config{},
@@ -447,7 +548,9 @@ func TestAllowLogic_Allow_Comment(t *testing.T) {
 		SystemRPC:     mockSystemRpc,
 		CommentRPC:    mockCommentRpc,
 		PostRPC:       mockPostRpc,
+		LockModel:     mockLockModel,
 	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
 	l := NewAllowLogic(context.Background(), svcCtx)
 
 	Convey("允许读", t, func() {
@@ -484,6 +587,7 @@ func TestAllowLogic_Allow_Comment(t *testing.T) {
 			},
 		}, nil)
 		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId: "UserId",
 			Object: ObjectComment,
 			Action: ActionWrite,
 		})
@@ -491,13 +595,14 @@ func TestAllowLogic_Allow_Comment(t *testing.T) {
 	})
 
 	Convey("允许评论发布者", t, func() {
-		mockCommentRpc.EXPECT().RetrieveCommentById(Any(), Any()).Return(&pb4.RetrieveCommentByIdResponse{
+		mockCommentRpc.EXPECT().RetrieveCommentById(Any(), Any()).Times(2).Return(&pb4.RetrieveCommentByIdResponse{
 			Comment: &pb4.Comment{
 				Id:       "CommentId",
 				AuthorId: "CommentAuthorId",
 			},
 		}, nil)
 		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any(), Any()).Return(&pb.RetrieveUserRoleResp{}, nil)
+		mockLockModel.EXPECT().FindOneByObjectAndObjectId(Any(), ObjectComment, "CommentId").Return(nil, model.ErrNotFound)
 		allow, _ := l.Allow(&pb2.AllowReq{
 			UserId:   "CommentAuthorId",
 			Object:   ObjectComment,
@@ -507,8 +612,29 @@ func TestAllowLogic_Allow_Comment(t *testing.T) {
 		So(allow.Allow, ShouldBeTrue)
 	})
 
+	Convey("不允许发布者修改已锁定的评论", t, func() {
+		mockCommentRpc.EXPECT().RetrieveCommentById(Any(), Any()).Times(3).Return(&pb4.RetrieveCommentByIdResponse{
+			Comment: &pb4.Comment{
+				Id:       "CommentId",
+				AuthorId: "CommentAuthorId",
+			},
+		}, nil)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any(), Any()).Times(2).Return(&pb.RetrieveUserRoleResp{}, nil)
+		mockLockModel.EXPECT().FindOneByObjectAndObjectId(Any(), ObjectComment, "CommentId").Return(&model.Lock{
+			Object:   ObjectComment,
+			ObjectId: "CommentId",
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "CommentAuthorId",
+			Object:   ObjectComment,
+			ObjectId: "CommentId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
 	Convey("帖子发布者操作评论", t, func() {
-		mockCommentRpc.EXPECT().RetrieveCommentById(Any(), Any()).Return(&pb4.RetrieveCommentByIdResponse{
+		mockCommentRpc.EXPECT().RetrieveCommentById(Any(), Any()).Times(2).Return(&pb4.RetrieveCommentByIdResponse{
 			Comment: &pb4.Comment{
 				Id:       "CommentId",
 				Type:     "post",
@@ -558,7 +684,7 @@ func TestAllowLogic_Allow_Comment(t *testing.T) {
 	})
 
 	Convey("动态发布者操作评论", t, func() {
-		mockCommentRpc.EXPECT().RetrieveCommentById(Any(), Any()).Return(&pb4.RetrieveCommentByIdResponse{
+		mockCommentRpc.EXPECT().RetrieveCommentById(Any(), Any()).Times(2).Return(&pb4.RetrieveCommentByIdResponse{
 			Comment: &pb4.Comment{
 				Id:       "CommentId",
 				Type:     "moment",
@@ -608,7 +734,7 @@ func TestAllowLogic_Allow_Comment(t *testing.T) {
 	})
 
 	Convey("动态发布者的社区管理员操作评论", t, func() {
-		mockCommentRpc.EXPECT().RetrieveCommentById(Any(), Any()).Return(&pb4.RetrieveCommentByIdResponse{
+		mockCommentRpc.EXPECT().RetrieveCommentById(Any(), Any()).Times(2).Return(&pb4.RetrieveCommentByIdResponse{
 			Comment: &pb4.Comment{
 				Id:       "CommentId",
 				Type:     "moment",
@@ -642,3 +768,1940 @@ func TestAllowLogic_Allow_Comment(t *testing.T) {
 	})
 
 }
+
+func TestAllowLogic_Allow_Rego(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockCollectionRpc := mock.NewMockCollectionRpc(ctrl)
+	mockMomentRpc := mock.NewMockMomentRpc(ctrl)
+	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
+	mockCommentRpc := mock.NewMockCommentRpc(ctrl)
+	mockPostRpc := mock.NewMockPostRpc(ctrl)
+	mockLockModel := mock.NewMockLockModel(ctrl)
+
+	svcCtx := &svc.ServiceContext{
+		Config:        config.Config{},
+		CollectionRPC: mockCollectionRpc,
+		MomentRPC:     mockMomentRpc,
+		SystemRPC:     mockSystemRpc,
+		CommentRPC:    mockCommentRpc,
+		PostRPC:       mockPostRpc,
+		LockModel:     mockLockModel,
+		Policy: svc.MustNewPolicy(config.PolicyConf{
+			Engine: config.EngineRego,
+			Rego: config.RegoConf{
+				Path:    "../../etc/authorization.rego",
+				Query:   "data.authorization.allow",
+				Objects: []string{ObjectMoment},
+			},
+		}),
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+	l := NewAllowLogic(context.Background(), svcCtx)
+
+	Convey("未交给rego的对象使用内置策略", t, func() {
+		allow, _ := l.Allow(&pb2.AllowReq{
+			Object: ObjectCommunity,
+			Action: ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("允许动态发布者写", t, func() {
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{}, nil)
+		mockMomentRpc.EXPECT().RetrieveMoment(Any(), Any()).Times(2).Return(&pb5.RetrieveMomentResp{
+			Moment: &pb5.Moment{
+				Id:          "MomentId",
+				UserId:      "MomentUserId",
+				CommunityId: "CommId",
+			},
+		}, nil)
+		mockSystemRpc.EXPECT().RetrieveCommunity(Any(), Any()).Return(&pb.RetrieveCommunityResp{
+			Community: &pb.Community{Id: "CommId"},
+		}, nil)
+		mockLockModel.EXPECT().FindOneByObjectAndObjectId(Any(), ObjectMoment, "MomentId").Return(nil, model.ErrNotFound)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "MomentUserId",
+			Object:   ObjectMoment,
+			ObjectId: "MomentId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("允许父社区管理员写", t, func() {
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{
+			Roles: []*pb.Role{
+				{
+					Type:        RoleCommunityAdmin,
+					CommunityId: "Parent",
+				},
+			},
+		}, nil)
+		mockMomentRpc.EXPECT().RetrieveMoment(Any(), Any()).Times(2).Return(&pb5.RetrieveMomentResp{
+			Moment: &pb5.Moment{
+				Id:          "MomentId",
+				UserId:      "MomentUserId",
+				CommunityId: "Child",
+			},
+		}, nil)
+		mockSystemRpc.EXPECT().RetrieveCommunity(Any(), Any()).Return(&pb.RetrieveCommunityResp{
+			Community: &pb.Community{
+				Id:       "Child",
+				ParentId: "Parent",
+			},
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "AdminId",
+			Object:   ObjectMoment,
+			ObjectId: "MomentId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("不允许其他用户写", t, func() {
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{
+			Roles: []*pb.Role{
+				{
+					Type:        RoleCommunityAdmin,
+					CommunityId: "AnotherCommId",
+				},
+			},
+		}, nil)
+		mockMomentRpc.EXPECT().RetrieveMoment(Any(), Any()).Return(&pb5.RetrieveMomentResp{
+			Moment: &pb5.Moment{
+				Id:          "MomentId",
+				UserId:      "MomentUserId",
+				CommunityId: "CommId",
+			},
+		}, nil)
+		mockSystemRpc.EXPECT().RetrieveCommunity(Any(), Any()).Return(&pb.RetrieveCommunityResp{
+			Community: &pb.Community{Id: "CommId"},
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "AnotherUserId",
+			Object:   ObjectMoment,
+			ObjectId: "MomentId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+}
+
+func TestAllowLogic_Allow_Casbin(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockCollectionRpc := mock.NewMockCollectionRpc(ctrl)
+	mockMomentRpc := mock.NewMockMomentRpc(ctrl)
+	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
+	mockCommentRpc := mock.NewMockCommentRpc(ctrl)
+	mockPostRpc := mock.NewMockPostRpc(ctrl)
+	mockGrantModel := mock.NewMockGrantModel(ctrl)
+	mockLockModel := mock.NewMockLockModel(ctrl)
+
+	svcCtx := &svc.ServiceContext{
+		Config:        config.Config{},
+		CollectionRPC: mockCollectionRpc,
+		MomentRPC:     mockMomentRpc,
+		SystemRPC:     mockSystemRpc,
+		CommentRPC:    mockCommentRpc,
+		PostRPC:       mockPostRpc,
+		GrantModel:    mockGrantModel,
+		LockModel:     mockLockModel,
+		Policy: svc.MustNewPolicy(config.PolicyConf{
+			Engine: config.EngineCasbin,
+			Casbin: config.CasbinConf{
+				Model:  "../../etc/casbin_model.conf",
+				Policy: "../../etc/casbin_policy.csv",
+			},
+		}),
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+	l := NewAllowLogic(context.Background(), svcCtx)
+
+	Convey("允许帖子发布者写", t, func() {
+		mockPostRpc.EXPECT().RetrievePost(Any(), Any()).Times(2).Return(&pb3.RetrievePostResp{
+			Post: &pb3.Post{
+				Id:     "PostId",
+				UserId: "PostUserId",
+			},
+		}, nil)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{}, nil)
+		mockGrantModel.EXPECT().ListByUserId(Any(), "PostUserId").Return(nil, nil)
+		mockLockModel.EXPECT().FindOneByObjectAndObjectId(Any(), ObjectPost, "PostId").Return(nil, model.ErrNotFound)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "PostUserId",
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("不允许非发布者写帖子", t, func() {
+		mockPostRpc.EXPECT().RetrievePost(Any(), Any()).Return(&pb3.RetrievePostResp{
+			Post: &pb3.Post{
+				Id:     "PostId",
+				UserId: "PostUserId",
+			},
+		}, nil)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{
+			Roles: []*pb.Role{
+				{
+					Type:        RoleCommunityAdmin,
+					CommunityId: "CommId",
+				},
+			},
+		}, nil)
+		mockGrantModel.EXPECT().ListByUserId(Any(), Any()).Return(nil, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "AnotherUserId",
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
+	Convey("允许父社区管理员写", t, func() {
+		mockCollectionRpc.EXPECT().RetrieveCat(Any(), Any()).Return(&pb6.RetrieveCatResp{
+			Cat: &pb6.Cat{
+				Id:          "CatId",
+				CommunityId: "Child",
+			},
+		}, nil)
+		mockSystemRpc.EXPECT().RetrieveCommunity(Any(), Any()).Return(&pb.RetrieveCommunityResp{
+			Community: &pb.Community{
+				Id:       "Child",
+				ParentId: "Parent",
+			},
+		}, nil)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{
+			Roles: []*pb.Role{
+				{
+					Type:        RoleCommunityAdmin,
+					CommunityId: "Parent",
+				},
+			},
+		}, nil)
+		mockGrantModel.EXPECT().ListByUserId(Any(), Any()).Return(nil, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "AdminId",
+			Object:   ObjectCat,
+			ObjectId: "CatId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("本服务授予的角色生效", t, func() {
+		mockSystemRpc.EXPECT().RetrieveNotice(Any(), Any()).Return(&pb.RetrieveNoticeResp{
+			Notice: &pb.Notice{
+				Id:          "NoticeId",
+				CommunityId: "CommId",
+			},
+		}, nil)
+		mockSystemRpc.EXPECT().RetrieveCommunity(Any(), Any()).Return(&pb.RetrieveCommunityResp{
+			Community: &pb.Community{Id: "CommId"},
+		}, nil)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{}, nil)
+		mockGrantModel.EXPECT().ListByUserId(Any(), "GrantedUserId").Return([]*model.Grant{
+			{
+				UserId:      "GrantedUserId",
+				Role:        RoleCommunityAdmin,
+				CommunityId: "CommId",
+			},
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "GrantedUserId",
+			Object:   ObjectNotice,
+			ObjectId: "NoticeId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("角色关系只在本次判定中生效", t, func() {
+		expectPost := func(times int) {
+			mockPostRpc.EXPECT().RetrievePost(Any(), Any()).Times(times).Return(&pb3.RetrievePostResp{
+				Post: &pb3.Post{
+					Id:     "PostId",
+					UserId: "PostUserId",
+				},
+			}, nil)
+		}
+		write := func() bool {
+			allow, _ := l.Allow(&pb2.AllowReq{
+				UserId:   "GrantedUserId",
+				Object:   ObjectPost,
+				ObjectId: "PostId",
+				Action:   ActionWrite,
+			})
+			return allow.Allow
+		}
+
+		// 允许写后还会查询发布者，判断修改期限和锁定限制
+		expectPost(2)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{}, nil)
+		mockGrantModel.EXPECT().ListByUserId(Any(), "GrantedUserId").Return([]*model.Grant{
+			{
+				UserId: "GrantedUserId",
+				Role:   RoleSuperAdmin,
+			},
+		}, nil)
+		So(write(), ShouldBeTrue)
+
+		// 撤销授予后，上一次判定的角色关系不再生效
+		expectPost(1)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{}, nil)
+		mockGrantModel.EXPECT().ListByUserId(Any(), "GrantedUserId").Return(nil, nil)
+		So(write(), ShouldBeFalse)
+	})
+
+	Convey("并发判定时用户的角色互不影响", t, func() {
+		mockSystemRpc.EXPECT().RetrieveNotice(Any(), Any()).AnyTimes().Return(&pb.RetrieveNoticeResp{
+			Notice: &pb.Notice{
+				Id:          "NoticeId",
+				CommunityId: "CommId",
+			},
+		}, nil)
+		mockSystemRpc.EXPECT().RetrieveCommunity(Any(), Any()).AnyTimes().Return(&pb.RetrieveCommunityResp{
+			Community: &pb.Community{Id: "CommId"},
+		}, nil)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).AnyTimes().Return(&pb.RetrieveUserRoleResp{}, nil)
+		mockGrantModel.EXPECT().ListByUserId(Any(), "GrantedUserId").AnyTimes().Return([]*model.Grant{
+			{
+				UserId:      "GrantedUserId",
+				Role:        RoleCommunityAdmin,
+				CommunityId: "CommId",
+			},
+		}, nil)
+		mockGrantModel.EXPECT().ListByUserId(Any(), "UserId").AnyTimes().Return(nil, nil)
+
+		results := make([]bool, 20)
+		var wg sync.WaitGroup
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				userId := "UserId"
+				if i%2 == 0 {
+					userId = "GrantedUserId"
+				}
+				allow, _ := NewAllowLogic(context.Background(), svcCtx).Allow(&pb2.AllowReq{
+					UserId:   userId,
+					Object:   ObjectNotice,
+					ObjectId: "NoticeId",
+					Action:   ActionWrite,
+				})
+				results[i] = allow.Allow
+			}(i)
+		}
+		wg.Wait()
+		for i, allow := range results {
+			So(allow, ShouldEqual, i%2 == 0)
+		}
+	})
+}
+
+func TestAllowLogic_Allow_Anonymous(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	// 匿名用户不应查询任何上游服务
+	svcCtx := &svc.ServiceContext{
+		Config:        config.Config{},
+		CollectionRPC: mock.NewMockCollectionRpc(ctrl),
+		MomentRPC:     mock.NewMockMomentRpc(ctrl),
+		SystemRPC:     mock.NewMockSystemRpc(ctrl),
+		CommentRPC:    mock.NewMockCommentRpc(ctrl),
+		PostRPC:       mock.NewMockPostRpc(ctrl),
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+	l := NewAllowLogic(context.Background(), svcCtx)
+
+	Convey("默认允许读", t, func() {
+		allow, _ := l.Allow(&pb2.AllowReq{
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("默认不允许写", t, func() {
+		allow, _ := l.Allow(&pb2.AllowReq{
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
+	Convey("按配置判定", t, func() {
+		svcCtx.Config.Anonymous.Rules = []config.AnonymousRule{
+			{Object: ObjectCat, Actions: []string{ActionRead}},
+		}
+		defer func() { svcCtx.Config.Anonymous.Rules = nil }()
+
+		allow, _ := l.Allow(&pb2.AllowReq{
+			Object:   ObjectCat,
+			ObjectId: "CatId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+
+		allow, _ = l.Allow(&pb2.AllowReq{
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+}
+
+func TestAllowLogic_Allow_PrivateCommunity(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockMomentRpc := mock.NewMockMomentRpc(ctrl)
+	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
+	mockVisibilityModel := mock.NewMockVisibilityModel(ctrl)
+	mockMemberModel := mock.NewMockMemberModel(ctrl)
+
+	svcCtx := &svc.ServiceContext{
+		Config:          config.Config{PrivateCommunity: true},
+		CollectionRPC:   mock.NewMockCollectionRpc(ctrl),
+		MomentRPC:       mockMomentRpc,
+		SystemRPC:       mockSystemRpc,
+		CommentRPC:      mock.NewMockCommentRpc(ctrl),
+		PostRPC:         mock.NewMockPostRpc(ctrl),
+		VisibilityModel: mockVisibilityModel,
+		MemberModel:     mockMemberModel,
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+	l := NewAllowLogic(context.Background(), svcCtx)
+
+	expectMoment := func() {
+		mockMomentRpc.EXPECT().RetrieveMoment(Any(), Any()).Return(&pb5.RetrieveMomentResp{
+			Moment: &pb5.Moment{
+				Id:          "MomentId",
+				UserId:      "MomentUserId",
+				CommunityId: "PrivateCommId",
+			},
+		}, nil)
+	}
+
+	Convey("允许读公开社区的动态", t, func() {
+		expectMoment()
+		mockVisibilityModel.EXPECT().FindOneByCommunityId(Any(), "PrivateCommId").Return(nil, model.ErrNotFound)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectMoment,
+			ObjectId: "MomentId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("不允许匿名用户读私有社区的动态", t, func() {
+		expectMoment()
+		mockVisibilityModel.EXPECT().FindOneByCommunityId(Any(), "PrivateCommId").Return(&model.Visibility{
+			CommunityId: "PrivateCommId",
+			Private:     true,
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			Object:   ObjectMoment,
+			ObjectId: "MomentId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
+	Convey("允许成员读私有社区的动态", t, func() {
+		expectMoment()
+		mockVisibilityModel.EXPECT().FindOneByCommunityId(Any(), "PrivateCommId").Return(&model.Visibility{
+			CommunityId: "PrivateCommId",
+			Private:     true,
+		}, nil)
+		mockMemberModel.EXPECT().FindOneByUserIdAndCommunityId(Any(), "MemberId", "PrivateCommId").Return(&model.Member{
+			UserId:      "MemberId",
+			CommunityId: "PrivateCommId",
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "MemberId",
+			Object:   ObjectMoment,
+			ObjectId: "MomentId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("不允许非成员读私有社区的动态", t, func() {
+		expectMoment()
+		mockVisibilityModel.EXPECT().FindOneByCommunityId(Any(), "PrivateCommId").Return(&model.Visibility{
+			CommunityId: "PrivateCommId",
+			Private:     true,
+		}, nil)
+		mockMemberModel.EXPECT().FindOneByUserIdAndCommunityId(Any(), "UserId", "PrivateCommId").Return(nil, model.ErrNotFound)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{
+			Roles: []*pb.Role{
+				{
+					Type: RoleUser,
+				},
+			},
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectMoment,
+			ObjectId: "MomentId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
+	Convey("允许社区管理员读私有社区的动态", t, func() {
+		expectMoment()
+		mockVisibilityModel.EXPECT().FindOneByCommunityId(Any(), "PrivateCommId").Return(&model.Visibility{
+			CommunityId: "PrivateCommId",
+			Private:     true,
+		}, nil)
+		mockMemberModel.EXPECT().FindOneByUserIdAndCommunityId(Any(), "AdminId", "PrivateCommId").Return(nil, model.ErrNotFound)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{
+			Roles: []*pb.Role{
+				{
+					Type:        RoleCommunityAdmin,
+					CommunityId: "PrivateCommId",
+				},
+			},
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "AdminId",
+			Object:   ObjectMoment,
+			ObjectId: "MomentId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+}
+
+func TestAllowLogic_Allow_ContentStatus(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
+	mockPostRpc := mock.NewMockPostRpc(ctrl)
+
+	svcCtx := &svc.ServiceContext{
+		Config: config.Config{
+			ContentStatus: config.ContentStatusConf{
+				Hidden: []int64{2},
+				Draft:  []int64{3},
+			},
+		},
+		CollectionRPC: mock.NewMockCollectionRpc(ctrl),
+		MomentRPC:     mock.NewMockMomentRpc(ctrl),
+		SystemRPC:     mockSystemRpc,
+		CommentRPC:    mock.NewMockCommentRpc(ctrl),
+		PostRPC:       mockPostRpc,
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+	l := NewAllowLogic(context.Background(), svcCtx)
+
+	expectPost := func(status int64) {
+		mockPostRpc.EXPECT().RetrievePost(Any(), Any()).Return(&pb3.RetrievePostResp{
+			Post: &pb3.Post{
+				Id:     "PostId",
+				UserId: "PostUserId",
+				Status: status,
+			},
+		}, nil)
+	}
+
+	Convey("允许读正常的帖子", t, func() {
+		expectPost(1)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("不允许其他用户读隐藏的帖子", t, func() {
+		expectPost(2)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{
+			Roles: []*pb.Role{
+				{
+					Type: RoleUser,
+				},
+			},
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "AnotherUserId",
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
+	Convey("允许超级管理员读隐藏的帖子", t, func() {
+		expectPost(2)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{
+			Roles: []*pb.Role{
+				{
+					Type: RoleSuperAdmin,
+				},
+			},
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "AdminId",
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("允许发布者读草稿", t, func() {
+		expectPost(3)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "PostUserId",
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("不允许超级管理员读草稿", t, func() {
+		expectPost(3)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "AdminId",
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+}
+
+func TestAllowLogic_Allow_Block(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockPostRpc := mock.NewMockPostRpc(ctrl)
+	mockCommentRpc := mock.NewMockCommentRpc(ctrl)
+	mockBlockModel := mock.NewMockBlockModel(ctrl)
+
+	svcCtx := &svc.ServiceContext{
+		Config:        config.Config{},
+		CollectionRPC: mock.NewMockCollectionRpc(ctrl),
+		MomentRPC:     mock.NewMockMomentRpc(ctrl),
+		SystemRPC:     mock.NewMockSystemRpc(ctrl),
+		CommentRPC:    mockCommentRpc,
+		PostRPC:       mockPostRpc,
+		BlockModel:    mockBlockModel,
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+	l := NewAllowLogic(context.Background(), svcCtx)
+
+	expectPost := func() {
+		mockPostRpc.EXPECT().RetrievePost(Any(), Any()).Return(&pb3.RetrievePostResp{
+			Post: &pb3.Post{
+				Id:     "PostId",
+				UserId: "PostUserId",
+			},
+		}, nil)
+	}
+
+	Convey("允许在帖子下新建评论", t, func() {
+		expectPost()
+		mockBlockModel.EXPECT().FindOneByUserIdAndBlockedUserId(Any(), "PostUserId", "UserId").Return(nil, model.ErrNotFound)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:       "UserId",
+			Object:       ObjectComment,
+			Action:       ActionWrite,
+			ParentObject: ObjectPost,
+			ParentId:     "PostId",
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("不允许在不存在的帖子下新建评论", t, func() {
+		mockPostRpc.EXPECT().RetrievePost(Any(), Any()).Return(nil, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:       "UserId",
+			Object:       ObjectComment,
+			Action:       ActionWrite,
+			ParentObject: ObjectPost,
+			ParentId:     "PostId",
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
+	Convey("不允许被拉黑的用户新建评论", t, func() {
+		expectPost()
+		mockBlockModel.EXPECT().FindOneByUserIdAndBlockedUserId(Any(), "PostUserId", "BlockedUserId").Return(&model.Block{
+			UserId:        "PostUserId",
+			BlockedUserId: "BlockedUserId",
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:       "BlockedUserId",
+			Object:       ObjectComment,
+			Action:       ActionWrite,
+			ParentObject: ObjectPost,
+			ParentId:     "PostId",
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
+	Convey("不允许被拉黑的评论发布者修改评论", t, func() {
+		mockSystemRpc := svcCtx.SystemRPC.(*mock.MockSystemRpc)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{}, nil)
+		mockCommentRpc.EXPECT().RetrieveCommentById(Any(), Any()).Return(&pb4.RetrieveCommentByIdResponse{
+			Comment: &pb4.Comment{
+				Id:       "CommentId",
+				Type:     ObjectPost,
+				ParentId: "PostId",
+				AuthorId: "BlockedUserId",
+			},
+		}, nil)
+		expectPost()
+		mockBlockModel.EXPECT().FindOneByUserIdAndBlockedUserId(Any(), "PostUserId", "BlockedUserId").Return(&model.Block{
+			UserId:        "PostUserId",
+			BlockedUserId: "BlockedUserId",
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "BlockedUserId",
+			Object:   ObjectComment,
+			ObjectId: "CommentId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+}
+
+func TestAllowLogic_Allow_Degrade(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockMomentRpc := mock.NewMockMomentRpc(ctrl)
+	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
+	mockPostRpc := mock.NewMockPostRpc(ctrl)
+	mockVisibilityModel := mock.NewMockVisibilityModel(ctrl)
+	decisions, _ := collection.NewCache(time.Minute)
+
+	svcCtx := &svc.ServiceContext{
+		Config: config.Config{
+			PrivateCommunity: true,
+			Degrade: config.DegradeConf{
+				Mode: config.DegradeClosed,
+				Rules: []config.DegradeRule{
+					{Object: ObjectMoment, Action: ActionRead, Mode: config.DegradeOpen},
+					{Object: ObjectCat, Mode: config.DegradeStale},
+				},
+			},
+		},
+		CollectionRPC:   mock.NewMockCollectionRpc(ctrl),
+		MomentRPC:       mockMomentRpc,
+		SystemRPC:       mockSystemRpc,
+		CommentRPC:      mock.NewMockCommentRpc(ctrl),
+		PostRPC:         mockPostRpc,
+		VisibilityModel: mockVisibilityModel,
+		Decisions:       decisions,
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+	l := func() *AllowLogic {
+		return NewAllowLogic(context.Background(), svcCtx)
+	}
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+
+	Convey("上游不可用时默认拒绝", t, func() {
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Times(2).Return(&pb.RetrieveUserRoleResp{
+			Roles: []*pb.Role{
+				{
+					Type: RoleUser,
+				},
+			},
+		}, nil)
+		mockPostRpc.EXPECT().RetrievePost(Any(), Any()).Return(nil, unavailable)
+		allow, _ := l().Allow(&pb2.AllowReq{
+			UserId:   "PostUserId",
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeFalse)
+		So(allow.Degraded, ShouldBeTrue)
+	})
+
+	Convey("对象不存在时不算降级", t, func() {
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{}, nil)
+		mockPostRpc.EXPECT().RetrievePost(Any(), Any()).Return(nil, status.Error(10301, "no such post"))
+		allow, _ := l().Allow(&pb2.AllowReq{
+			UserId:   "PostUserId",
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeFalse)
+		So(allow.Degraded, ShouldBeFalse)
+	})
+
+	Convey("上游不可用时仍然允许超级管理员写", t, func() {
+		mockSystemRpc.EXPECT().RetrieveNotice(Any(), Any()).Return(nil, unavailable)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{
+			Roles: []*pb.Role{
+				{
+					Type: RoleSuperAdmin,
+				},
+			},
+		}, nil)
+		allow, _ := l().Allow(&pb2.AllowReq{
+			UserId:   "AdminId",
+			Object:   ObjectNotice,
+			ObjectId: "NoticeId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeTrue)
+		So(allow.Degraded, ShouldBeTrue)
+	})
+
+	Convey("按配置允许读", t, func() {
+		mockMomentRpc.EXPECT().RetrieveMoment(Any(), Any()).Return(nil, unavailable)
+		allow, _ := l().Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectMoment,
+			ObjectId: "MomentId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+		So(allow.Degraded, ShouldBeTrue)
+	})
+
+	Convey("使用最近一次正常判定的结果", t, func() {
+		mockCollectionRpc := svcCtx.CollectionRPC.(*mock.MockCollectionRpc)
+		mockCollectionRpc.EXPECT().RetrieveCat(Any(), Any()).Return(&pb6.RetrieveCatResp{
+			Cat: &pb6.Cat{
+				Id:          "CatId",
+				CommunityId: "CommId",
+			},
+		}, nil)
+		mockVisibilityModel.EXPECT().FindOneByCommunityId(Any(), "CommId").Return(nil, model.ErrNotFound)
+		allow, _ := l().Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectCat,
+			ObjectId: "CatId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+		So(allow.Degraded, ShouldBeFalse)
+
+		mockCollectionRpc.EXPECT().RetrieveCat(Any(), Any()).Return(nil, unavailable)
+		allow, _ = l().Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectCat,
+			ObjectId: "CatId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+		So(allow.Degraded, ShouldBeTrue)
+
+		mockCollectionRpc.EXPECT().RetrieveCat(Any(), Any()).Return(nil, unavailable)
+		allow, _ = l().Allow(&pb2.AllowReq{
+			UserId:   "AnotherUserId",
+			Object:   ObjectCat,
+			ObjectId: "CatId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeFalse)
+		So(allow.Degraded, ShouldBeTrue)
+	})
+}
+
+func TestAllowLogic_Allow_Trace(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer otel.SetTracerProvider(provider)
+
+	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
+	mockCommentRpc := mock.NewMockCommentRpc(ctrl)
+	mockPostRpc := mock.NewMockPostRpc(ctrl)
+
+	svcCtx := &svc.ServiceContext{
+		Config:        config.Config{},
+		CollectionRPC: mock.NewMockCollectionRpc(ctrl),
+		MomentRPC:     mock.NewMockMomentRpc(ctrl),
+		SystemRPC:     mockSystemRpc,
+		CommentRPC:    mockCommentRpc,
+		PostRPC:       mockPostRpc,
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+
+	Convey("评论判定从属对象时每个策略函数和上游查询都有 span", t, func() {
+		// 降级时再查询一次是否是超级管理员
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Times(3).Return(&pb.RetrieveUserRoleResp{}, nil)
+		mockCommentRpc.EXPECT().RetrieveCommentById(Any(), Any()).Return(&pb4.RetrieveCommentByIdResponse{
+			Comment: &pb4.Comment{
+				Id:       "CommentId",
+				AuthorId: "CommentAuthorId",
+				Type:     ObjectPost,
+				ParentId: "PostId",
+			},
+		}, nil)
+		mockPostRpc.EXPECT().RetrievePost(Any(), Any()).Return(nil, status.Error(codes.Unavailable, "unavailable"))
+		allow, _ := NewAllowLogic(context.Background(), svcCtx).Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectComment,
+			ObjectId: "CommentId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeFalse)
+
+		spans := exporter.GetSpans()
+		names := make([]string, 0, len(spans))
+		byName := make(map[string]tracetest.SpanStub, len(spans))
+		for _, s := range spans {
+			names = append(names, s.Name)
+			byName[s.Name] = s
+		}
+		So(names, ShouldResemble, []string{
+			"lookup/system/RetrieveUserRole",
+			"resolve/comment",
+			"lookup/system/RetrieveUserRole",
+			"resolve/post",
+			"policy/post",
+			"policy/comment",
+			"lookup/system/RetrieveUserRole",
+			"allow",
+		})
+
+		root := byName["allow"]
+		comment := byName["policy/comment"]
+		post := byName["policy/post"]
+		lookup := byName["resolve/post"]
+		So(comment.Parent.SpanID(), ShouldEqual, root.SpanContext.SpanID())
+		So(post.Parent.SpanID(), ShouldEqual, comment.SpanContext.SpanID())
+		So(lookup.Parent.SpanID(), ShouldEqual, post.SpanContext.SpanID())
+		So(lookup.Status.Code, ShouldEqual, otelcodes.Error)
+		So(post.Attributes, ShouldContain, attribute.String(TraceObject, ObjectPost))
+		So(post.Attributes, ShouldContain, attribute.Bool(TraceDecision, false))
+		So(root.Attributes, ShouldContain, attribute.Bool(TraceDegraded, true))
+	})
+}
+
+func TestAllowLogic_Allow_Resolver(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
+	mockLockModel := mock.NewMockLockModel(ctrl)
+
+	svcCtx := &svc.ServiceContext{
+		Config:        config.Config{},
+		CollectionRPC: mock.NewMockCollectionRpc(ctrl),
+		MomentRPC:     mock.NewMockMomentRpc(ctrl),
+		SystemRPC:     mockSystemRpc,
+		CommentRPC:    mock.NewMockCommentRpc(ctrl),
+		PostRPC:       mock.NewMockPostRpc(ctrl),
+		LockModel:     mockLockModel,
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+	// 新的服务只注册解析器，使用通用策略
+	svcCtx.Resolvers.Register("album", resolver.ResolverFunc(func(_ context.Context, id string) (*resolver.Resource, error) {
+		if id != "AlbumId" {
+			return nil, nil
+		}
+		return &resolver.Resource{
+			Id:          id,
+			OwnerId:     "AlbumOwnerId",
+			CommunityId: "CommunityId",
+		}, nil
+	}))
+	l := NewAllowLogic(context.Background(), svcCtx)
+
+	Convey("允许读", t, func() {
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   "album",
+			ObjectId: "AlbumId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("允许发布者写", t, func() {
+		mockLockModel.EXPECT().FindOneByObjectAndObjectId(Any(), "album", "AlbumId").Return(nil, model.ErrNotFound)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "AlbumOwnerId",
+			Object:   "album",
+			ObjectId: "AlbumId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("允许所属社区的管理员写", t, func() {
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{
+			Roles: []*pb.Role{
+				{
+					Type:        RoleCommunityAdmin,
+					CommunityId: "CommunityId",
+				},
+			},
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "AdminId",
+			Object:   "album",
+			ObjectId: "AlbumId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("对象不存在时不允许写", t, func() {
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "AlbumOwnerId",
+			Object:   "album",
+			ObjectId: "AnotherAlbumId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
+	Convey("未注册解析器的对象不允许", t, func() {
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   "video",
+			ObjectId: "VideoId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+}
+
+func TestAllowLogic_Allow_User(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
+	mockProfileModel := mock.NewMockProfileModel(ctrl)
+	mockFollowModel := mock.NewMockFollowModel(ctrl)
+
+	svcCtx := &svc.ServiceContext{
+		Config:        config.Config{},
+		CollectionRPC: mock.NewMockCollectionRpc(ctrl),
+		MomentRPC:     mock.NewMockMomentRpc(ctrl),
+		SystemRPC:     mockSystemRpc,
+		CommentRPC:    mock.NewMockCommentRpc(ctrl),
+		PostRPC:       mock.NewMockPostRpc(ctrl),
+		ProfileModel:  mockProfileModel,
+		FollowModel:   mockFollowModel,
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+	l := NewAllowLogic(context.Background(), svcCtx)
+
+	Convey("允许用户修改自己的资料", t, func() {
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectUser,
+			ObjectId: "UserId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("不允许修改其他用户的资料", t, func() {
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectUser,
+			ObjectId: "AnotherUserId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
+	Convey("允许超级管理员修改任意资料", t, func() {
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{
+			Roles: []*pb.Role{
+				{
+					Type: RoleSuperAdmin,
+				},
+			},
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "AdminId",
+			Object:   ObjectUser,
+			ObjectId: "AnotherUserId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("未设置可见性时资料公开", t, func() {
+		mockProfileModel.EXPECT().FindOneByUserId(Any(), "AnotherUserId").Return(nil, model.ErrNotFound)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			Object:   ObjectUser,
+			ObjectId: "AnotherUserId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("仅关注者可见的资料", t, func() {
+		mockProfileModel.EXPECT().FindOneByUserId(Any(), "AnotherUserId").Times(3).Return(&model.Profile{
+			UserId:     "AnotherUserId",
+			Visibility: ProfileFollowers,
+		}, nil)
+		mockFollowModel.EXPECT().FindOneByUserIdAndFollowedUserId(Any(), "FollowerId", "AnotherUserId").
+			Return(&model.Follow{}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "FollowerId",
+			Object:   ObjectUser,
+			ObjectId: "AnotherUserId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+
+		mockFollowModel.EXPECT().FindOneByUserIdAndFollowedUserId(Any(), "UserId", "AnotherUserId").
+			Return(nil, model.ErrNotFound)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{}, nil)
+		allow, _ = l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectUser,
+			ObjectId: "AnotherUserId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeFalse)
+
+		allow, _ = l.Allow(&pb2.AllowReq{
+			Object:   ObjectUser,
+			ObjectId: "AnotherUserId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
+	Convey("私密资料只允许本人和超级管理员读", t, func() {
+		svcCtx.Config.UserProfile.Visibility = ProfilePrivate
+		defer func() {
+			svcCtx.Config.UserProfile.Visibility = ""
+		}()
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectUser,
+			ObjectId: "UserId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+
+		mockProfileModel.EXPECT().FindOneByUserId(Any(), "AnotherUserId").Times(2).Return(nil, model.ErrNotFound)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{}, nil)
+		allow, _ = l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectUser,
+			ObjectId: "AnotherUserId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeFalse)
+
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{
+			Roles: []*pb.Role{
+				{
+					Type: RoleSuperAdmin,
+				},
+			},
+		}, nil)
+		allow, _ = l.Allow(&pb2.AllowReq{
+			UserId:   "AdminId",
+			Object:   ObjectUser,
+			ObjectId: "AnotherUserId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+}
+
+func TestAllowLogic_Allow_Admin(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
+
+	svcCtx := &svc.ServiceContext{
+		Config:        config.Config{},
+		CollectionRPC: mock.NewMockCollectionRpc(ctrl),
+		MomentRPC:     mock.NewMockMomentRpc(ctrl),
+		SystemRPC:     mockSystemRpc,
+		CommentRPC:    mock.NewMockCommentRpc(ctrl),
+		PostRPC:       mock.NewMockPostRpc(ctrl),
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+	l := NewAllowLogic(context.Background(), svcCtx)
+
+	communityAdmin := func() {
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{
+			Roles: []*pb.Role{
+				{
+					Type:        RoleCommunityAdmin,
+					CommunityId: "CommunityId",
+				},
+			},
+		}, nil)
+	}
+
+	Convey("允许读管理员信息", t, func() {
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectAdmin,
+			ObjectId: "AdminId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("允许社区管理员在自己的社区新建管理员信息", t, func() {
+		communityAdmin()
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:       "UserId",
+			Object:       ObjectAdmin,
+			Action:       ActionWrite,
+			ParentObject: ObjectCommunity,
+			ParentId:     "CommunityId",
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("新建管理员信息必须指定社区", t, func() {
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId: "UserId",
+			Object: ObjectAdmin,
+			Action: ActionWrite,
+		})
+		So(allow.Allow, ShouldBeFalse)
+
+		allow, _ = l.Allow(&pb2.AllowReq{
+			UserId:       "UserId",
+			Object:       ObjectAdmin,
+			Action:       ActionWrite,
+			ParentObject: ObjectPost,
+			ParentId:     "CommunityId",
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
+	Convey("不允许社区管理员修改其他社区的管理员信息", t, func() {
+		mockSystemRpc.EXPECT().RetrieveAdmin(Any(), Any()).Return(&pb.RetrieveAdminResp{
+			Admin: &pb.Admin{
+				Id:          "AdminId",
+				CommunityId: "OtherCommunityId",
+			},
+		}, nil)
+		communityAdmin()
+		mockSystemRpc.EXPECT().RetrieveCommunity(Any(), Any()).Return(&pb.RetrieveCommunityResp{
+			Community: &pb.Community{
+				Id: "OtherCommunityId",
+			},
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectAdmin,
+			ObjectId: "AdminId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
+	Convey("不允许社区管理员把管理员信息移到其他社区", t, func() {
+		mockSystemRpc.EXPECT().RetrieveAdmin(Any(), Any()).Return(&pb.RetrieveAdminResp{
+			Admin: &pb.Admin{
+				Id:          "AdminId",
+				CommunityId: "CommunityId",
+			},
+		}, nil)
+		communityAdmin()
+		communityAdmin()
+		mockSystemRpc.EXPECT().RetrieveCommunity(Any(), Any()).Return(&pb.RetrieveCommunityResp{
+			Community: &pb.Community{
+				Id: "OtherCommunityId",
+			},
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:       "UserId",
+			Object:       ObjectAdmin,
+			ObjectId:     "AdminId",
+			Action:       ActionWrite,
+			ParentObject: ObjectCommunity,
+			ParentId:     "OtherCommunityId",
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
+	Convey("允许超级管理员删除任意管理员信息", t, func() {
+		mockSystemRpc.EXPECT().RetrieveAdmin(Any(), Any()).Return(&pb.RetrieveAdminResp{
+			Admin: &pb.Admin{
+				Id:          "AdminId",
+				CommunityId: "OtherCommunityId",
+			},
+		}, nil)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{
+			Roles: []*pb.Role{
+				{
+					Type: RoleSuperAdmin,
+				},
+			},
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "AdminUserId",
+			Object:   ObjectAdmin,
+			ObjectId: "AdminId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("管理员信息不存在时不允许写", t, func() {
+		mockSystemRpc.EXPECT().RetrieveAdmin(Any(), Any()).Return(&pb.RetrieveAdminResp{}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectAdmin,
+			ObjectId: "AdminId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+}
+
+func TestAllowLogic_Allow_Role(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
+
+	svcCtx := &svc.ServiceContext{
+		Config:        config.Config{},
+		CollectionRPC: mock.NewMockCollectionRpc(ctrl),
+		MomentRPC:     mock.NewMockMomentRpc(ctrl),
+		SystemRPC:     mockSystemRpc,
+		CommentRPC:    mock.NewMockCommentRpc(ctrl),
+		PostRPC:       mock.NewMockPostRpc(ctrl),
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+	l := NewAllowLogic(context.Background(), svcCtx)
+
+	// 调用者是 CommunityId 的管理员，CommunityId 的父社区是 ParentCommunityId，子社区是 SubCommunityId
+	roles := func(roles ...*pb.Role) {
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{Roles: roles}, nil)
+	}
+	communityAdmin := &pb.Role{
+		Type:        RoleCommunityAdmin,
+		CommunityId: "CommunityId",
+	}
+	superAdmin := &pb.Role{
+		Type: RoleSuperAdmin,
+	}
+	community := func(id, parentId string) {
+		mockSystemRpc.EXPECT().RetrieveCommunity(Any(), Any()).Return(&pb.RetrieveCommunityResp{
+			Community: &pb.Community{
+				Id:       id,
+				ParentId: parentId,
+			},
+		}, nil)
+	}
+	grant := func(role *pb2.Role) bool {
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectRole,
+			ObjectId: "AnotherUserId",
+			Action:   ActionWrite,
+			Role:     role,
+		})
+		return allow.Allow
+	}
+
+	Convey("允许超级管理员授予或撤销任意角色", t, func() {
+		roles(superAdmin)
+		So(grant(&pb2.Role{Type: RoleSuperAdmin}), ShouldBeTrue)
+
+		roles(superAdmin)
+		So(grant(&pb2.Role{Type: RoleCommunityAdmin, CommunityId: "OtherCommunityId"}), ShouldBeTrue)
+	})
+
+	Convey("社区管理员不能授予或撤销超级管理员", t, func() {
+		roles(communityAdmin)
+		So(grant(&pb2.Role{Type: RoleSuperAdmin}), ShouldBeFalse)
+
+		// 超级管理员角色带上自己管理的社区也不行
+		roles(communityAdmin)
+		So(grant(&pb2.Role{Type: RoleSuperAdmin, CommunityId: "CommunityId"}), ShouldBeFalse)
+	})
+
+	Convey("允许社区管理员授予或撤销所管理社区及子社区的管理员", t, func() {
+		roles(communityAdmin)
+		So(grant(&pb2.Role{Type: RoleCommunityAdmin, CommunityId: "CommunityId"}), ShouldBeTrue)
+
+		roles(communityAdmin)
+		community("SubCommunityId", "CommunityId")
+		So(grant(&pb2.Role{Type: RoleCommunityAdmin, CommunityId: "SubCommunityId"}), ShouldBeTrue)
+	})
+
+	Convey("社区管理员不能授予或撤销父社区和其他社区的管理员", t, func() {
+		roles(communityAdmin)
+		community("ParentCommunityId", "")
+		So(grant(&pb2.Role{Type: RoleCommunityAdmin, CommunityId: "ParentCommunityId"}), ShouldBeFalse)
+
+		roles(communityAdmin)
+		community("OtherCommunityId", "ParentCommunityId")
+		So(grant(&pb2.Role{Type: RoleCommunityAdmin, CommunityId: "OtherCommunityId"}), ShouldBeFalse)
+	})
+
+	Convey("社区管理员不能授予不属于任何社区的角色", t, func() {
+		roles(communityAdmin)
+		So(grant(&pb2.Role{Type: RoleCommunityAdmin}), ShouldBeFalse)
+
+		roles(communityAdmin)
+		So(grant(&pb2.Role{Type: RoleUser}), ShouldBeFalse)
+	})
+
+	Convey("没有社区的社区管理员角色不管理任何社区", t, func() {
+		roles(&pb.Role{Type: RoleCommunityAdmin})
+		So(grant(&pb2.Role{Type: RoleCommunityAdmin, CommunityId: "CommunityId"}), ShouldBeFalse)
+	})
+
+	Convey("普通用户不能授予自己任何角色", t, func() {
+		roles(&pb.Role{Type: RoleUser})
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectRole,
+			ObjectId: "UserId",
+			Action:   ActionWrite,
+			Role:     &pb2.Role{Type: RoleCommunityAdmin, CommunityId: "CommunityId"},
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
+	Convey("没有指定角色时不允许写", t, func() {
+		So(grant(nil), ShouldBeFalse)
+		So(grant(&pb2.Role{CommunityId: "CommunityId"}), ShouldBeFalse)
+	})
+
+	Convey("只允许本人和超级管理员读角色", t, func() {
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectRole,
+			ObjectId: "UserId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+
+		roles(communityAdmin)
+		allow, _ = l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectRole,
+			ObjectId: "AnotherUserId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeFalse)
+
+		roles(superAdmin)
+		allow, _ = l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectRole,
+			ObjectId: "AnotherUserId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+
+		allow, _ = l.Allow(&pb2.AllowReq{
+			Object:   ObjectRole,
+			ObjectId: "AnotherUserId",
+			Action:   ActionRead,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
+	Convey("不同角色的判定不共用降级时保留的结果", t, func() {
+		in := &pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectRole,
+			ObjectId: "AnotherUserId",
+			Action:   ActionWrite,
+			Role:     &pb2.Role{Type: RoleCommunityAdmin, CommunityId: "CommunityId"},
+		}
+		key := decisionKey(in)
+		in.Role = &pb2.Role{Type: RoleSuperAdmin}
+		So(decisionKey(in), ShouldNotEqual, key)
+	})
+}
+
+func TestAllowLogic_Allow_CommentParent(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
+	mockCollectionRpc := mock.NewMockCollectionRpc(ctrl)
+	mockMomentRpc := mock.NewMockMomentRpc(ctrl)
+	mockPostRpc := mock.NewMockPostRpc(ctrl)
+	mockCommentRpc := mock.NewMockCommentRpc(ctrl)
+	mockBlockModel := mock.NewMockBlockModel(ctrl)
+
+	svcCtx := &svc.ServiceContext{
+		Config:        config.Config{},
+		CollectionRPC: mockCollectionRpc,
+		MomentRPC:     mockMomentRpc,
+		SystemRPC:     mockSystemRpc,
+		CommentRPC:    mockCommentRpc,
+		PostRPC:       mockPostRpc,
+		BlockModel:    mockBlockModel,
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+	l := NewAllowLogic(context.Background(), svcCtx)
+
+	expectComment := func(id, authorId, parentType, parentId string) {
+		mockCommentRpc.EXPECT().RetrieveCommentById(Any(), Any()).Return(&pb4.RetrieveCommentByIdResponse{
+			Comment: &pb4.Comment{
+				Id:       id,
+				AuthorId: authorId,
+				Type:     parentType,
+				ParentId: parentId,
+			},
+		}, nil)
+	}
+	expectRoles := func(roles ...*pb.Role) {
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{Roles: roles}, nil)
+	}
+
+	Convey("允许社区管理员删除猫咪下的评论", t, func() {
+		expectRoles(&pb.Role{Type: RoleCommunityAdmin, CommunityId: "CommunityId"})
+		expectComment("CommentId", "CommentAuthorId", ObjectCat, "CatId")
+		// 允许写后还会查询评论发布者，判断修改期限和锁定限制
+		expectComment("CommentId", "CommentAuthorId", ObjectCat, "CatId")
+		mockCollectionRpc.EXPECT().RetrieveCat(Any(), Any()).Return(&pb6.RetrieveCatResp{
+			Cat: &pb6.Cat{
+				Id:          "CatId",
+				CommunityId: "CommunityId",
+			},
+		}, nil)
+		expectRoles(&pb.Role{Type: RoleCommunityAdmin, CommunityId: "CommunityId"})
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectComment,
+			ObjectId: "CommentId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("不允许普通用户修改通知下他人的评论", t, func() {
+		expectRoles()
+		expectComment("CommentId", "CommentAuthorId", ObjectNotice, "NoticeId")
+		mockSystemRpc.EXPECT().RetrieveNotice(Any(), Any()).Return(&pb.RetrieveNoticeResp{
+			Notice: &pb.Notice{
+				Id:          "NoticeId",
+				CommunityId: "CommunityId",
+			},
+		}, nil)
+		expectRoles()
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectComment,
+			ObjectId: "CommentId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
+	Convey("允许在猫咪和通知下新建评论", t, func() {
+		mockCollectionRpc.EXPECT().RetrieveCat(Any(), Any()).Return(&pb6.RetrieveCatResp{
+			Cat: &pb6.Cat{
+				Id: "CatId",
+			},
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:       "UserId",
+			Object:       ObjectComment,
+			Action:       ActionWrite,
+			ParentObject: ObjectCat,
+			ParentId:     "CatId",
+		})
+		So(allow.Allow, ShouldBeTrue)
+
+		mockSystemRpc.EXPECT().RetrieveNotice(Any(), Any()).Return(&pb.RetrieveNoticeResp{
+			Notice: &pb.Notice{
+				Id: "NoticeId",
+			},
+		}, nil)
+		allow, _ = l.Allow(&pb2.AllowReq{
+			UserId:       "UserId",
+			Object:       ObjectComment,
+			Action:       ActionWrite,
+			ParentObject: ObjectNotice,
+			ParentId:     "NoticeId",
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("不允许在没有内置策略的对象下新建评论", t, func() {
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:       "UserId",
+			Object:       ObjectComment,
+			Action:       ActionWrite,
+			ParentObject: "album",
+			ParentId:     "AlbumId",
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
+	Convey("允许动态发布者删除动态下评论的回复", t, func() {
+		expectRoles()
+		expectComment("ReplyId", "ReplyAuthorId", ObjectComment, "CommentId")
+		expectComment("CommentId", "CommentAuthorId", ObjectMoment, "MomentId")
+		expectComment("ReplyId", "ReplyAuthorId", ObjectComment, "CommentId")
+		mockMomentRpc.EXPECT().RetrieveMoment(Any(), Any()).Return(&pb5.RetrieveMomentResp{
+			Moment: &pb5.Moment{
+				Id:     "MomentId",
+				UserId: "UserId",
+			},
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:   "UserId",
+			Object:   ObjectComment,
+			ObjectId: "ReplyId",
+			Action:   ActionWrite,
+		})
+		So(allow.Allow, ShouldBeTrue)
+	})
+
+	Convey("被帖子发布者拉黑的用户不能回复帖子下的评论", t, func() {
+		expectComment("CommentId", "CommentAuthorId", ObjectPost, "PostId")
+		mockPostRpc.EXPECT().RetrievePost(Any(), Any()).Return(&pb3.RetrievePostResp{
+			Post: &pb3.Post{
+				Id:     "PostId",
+				UserId: "PostUserId",
+			},
+		}, nil)
+		mockBlockModel.EXPECT().FindOneByUserIdAndBlockedUserId(Any(), "PostUserId", "UserId").Return(&model.Block{}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:       "UserId",
+			Object:       ObjectComment,
+			Action:       ActionWrite,
+			ParentObject: ObjectComment,
+			ParentId:     "CommentId",
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+
+	Convey("回复链成环时不允许回复", t, func() {
+		mockCommentRpc.EXPECT().RetrieveCommentById(Any(), Any()).Times(maxCommentDepth).
+			Return(&pb4.RetrieveCommentByIdResponse{
+				Comment: &pb4.Comment{
+					Id:       "CommentId",
+					AuthorId: "CommentAuthorId",
+					Type:     ObjectComment,
+					ParentId: "CommentId",
+				},
+			}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:       "UserId",
+			Object:       ObjectComment,
+			Action:       ActionWrite,
+			ParentObject: ObjectComment,
+			ParentId:     "CommentId",
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+}
+
+func TestAllowLogic_Allow_Interaction(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
+	mockPostRpc := mock.NewMockPostRpc(ctrl)
+	mockBlockModel := mock.NewMockBlockModel(ctrl)
+	mockReportModel := mock.NewMockReportModel(ctrl)
+
+	svcCtx := &svc.ServiceContext{
+		Config:        config.Config{},
+		CollectionRPC: mock.NewMockCollectionRpc(ctrl),
+		MomentRPC:     mock.NewMockMomentRpc(ctrl),
+		SystemRPC:     mockSystemRpc,
+		CommentRPC:    mock.NewMockCommentRpc(ctrl),
+		PostRPC:       mockPostRpc,
+		BlockModel:    mockBlockModel,
+		ReportModel:   mockReportModel,
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+	l := NewAllowLogic(context.Background(), svcCtx)
+
+	expectPost := func(userId string, status int64) {
+		mockPostRpc.EXPECT().RetrievePost(Any(), Any()).Return(&pb3.RetrievePostResp{
+			Post: &pb3.Post{
+				Id:     "PostId",
+				UserId: userId,
+				Status: status,
+			},
+		}, nil)
+	}
+	interact := func(object, userId, parentObject, parentId string) *pb2.AllowResp {
+		resp, _ := NewAllowLogic(context.Background(), svcCtx).Allow(&pb2.AllowReq{
+			UserId:       userId,
+			Object:       object,
+			Action:       ActionWrite,
+			ParentObject: parentObject,
+			ParentId:     parentId,
+		})
+		return resp
+	}
+
+	Convey("允许给他人的帖子点赞", t, func() {
+		expectPost("PostUserId", 0)
+		So(interact(ObjectLike, "UserId", ObjectPost, "PostId").Allow, ShouldBeTrue)
+	})
+
+	Convey("按配置不允许给自己的帖子点赞", t, func() {
+		expectPost("UserId", 0)
+		So(interact(ObjectLike, "UserId", ObjectPost, "PostId").Allow, ShouldBeTrue)
+
+		svcCtx.Config.Interaction.NoSelfLike = []string{ObjectPost}
+		defer func() {
+			svcCtx.Config.Interaction.NoSelfLike = nil
+		}()
+		expectPost("UserId", 0)
+		So(interact(ObjectLike, "UserId", ObjectPost, "PostId").Allow, ShouldBeFalse)
+	})
+
+	Convey("不允许给不存在或不可读的帖子点赞", t, func() {
+		mockPostRpc.EXPECT().RetrievePost(Any(), Any()).Return(nil, nil)
+		So(interact(ObjectLike, "UserId", ObjectPost, "PostId").Allow, ShouldBeFalse)
+
+		svcCtx.Config.ContentStatus.Hidden = []int64{1}
+		defer func() {
+			svcCtx.Config.ContentStatus.Hidden = nil
+		}()
+		expectPost("PostUserId", 1)
+		expectPost("PostUserId", 1)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{}, nil)
+		So(interact(ObjectLike, "UserId", ObjectPost, "PostId").Allow, ShouldBeFalse)
+	})
+
+	Convey("允许关注没有拉黑关系的用户", t, func() {
+		mockBlockModel.EXPECT().FindOneByUserIdAndBlockedUserId(Any(), "AnotherUserId", "UserId").Return(nil, model.ErrNotFound)
+		mockBlockModel.EXPECT().FindOneByUserIdAndBlockedUserId(Any(), "UserId", "AnotherUserId").Return(nil, model.ErrNotFound)
+		So(interact(ObjectFollow, "UserId", ObjectUser, "AnotherUserId").Allow, ShouldBeTrue)
+	})
+
+	Convey("不允许关注拉黑了自己或被自己拉黑的用户", t, func() {
+		mockBlockModel.EXPECT().FindOneByUserIdAndBlockedUserId(Any(), "AnotherUserId", "UserId").Return(&model.Block{}, nil)
+		So(interact(ObjectFollow, "UserId", ObjectUser, "AnotherUserId").Allow, ShouldBeFalse)
+
+		mockBlockModel.EXPECT().FindOneByUserIdAndBlockedUserId(Any(), "AnotherUserId", "UserId").Return(nil, model.ErrNotFound)
+		mockBlockModel.EXPECT().FindOneByUserIdAndBlockedUserId(Any(), "UserId", "AnotherUserId").Return(&model.Block{}, nil)
+		So(interact(ObjectFollow, "UserId", ObjectUser, "AnotherUserId").Allow, ShouldBeFalse)
+	})
+
+	Convey("只能关注其他用户", t, func() {
+		So(interact(ObjectFollow, "UserId", ObjectUser, "UserId").Allow, ShouldBeFalse)
+		So(interact(ObjectFollow, "UserId", ObjectPost, "PostId").Allow, ShouldBeFalse)
+	})
+
+	Convey("不允许举报自己的帖子", t, func() {
+		expectPost("UserId", 0)
+		So(interact(ObjectReport, "UserId", ObjectPost, "PostId").Allow, ShouldBeFalse)
+	})
+
+	Convey("同一对象未处理的举报只能有一个", t, func() {
+		expectPost("PostUserId", 0)
+		mockReportModel.EXPECT().FindOneOpenByUserIdAndObject(Any(), "UserId", ObjectPost, "PostId").Return(nil, model.ErrNotFound)
+		So(interact(ObjectReport, "UserId", ObjectPost, "PostId").Allow, ShouldBeTrue)
+
+		expectPost("PostUserId", 0)
+		mockReportModel.EXPECT().FindOneOpenByUserIdAndObject(Any(), "UserId", ObjectPost, "PostId").Return(&model.Report{}, nil)
+		So(interact(ObjectReport, "UserId", ObjectPost, "PostId").Allow, ShouldBeFalse)
+	})
+
+	Convey("查询举报失败时降级", t, func() {
+		expectPost("PostUserId", 0)
+		mockReportModel.EXPECT().FindOneOpenByUserIdAndObject(Any(), "UserId", ObjectPost, "PostId").Return(nil, errors.New("timeout"))
+		// 降级时仍允许超级管理员写
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{}, nil)
+		resp := interact(ObjectReport, "UserId", ObjectPost, "PostId")
+		So(resp.Allow, ShouldBeFalse)
+		So(resp.Degraded, ShouldBeTrue)
+	})
+
+	Convey("只允许管理员读举报", t, func() {
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{
+			Roles: []*pb.Role{
+				{
+					Type: RoleSuperAdmin,
+				},
+			},
+		}, nil)
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId: "AdminId",
+			Object: ObjectReport,
+			Action: ActionRead,
+		})
+		So(allow.Allow, ShouldBeTrue)
+
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{}, nil)
+		allow, _ = l.Allow(&pb2.AllowReq{
+			UserId: "UserId",
+			Object: ObjectReport,
+			Action: ActionRead,
+		})
+		So(allow.Allow, ShouldBeFalse)
+
+		allow, _ = l.Allow(&pb2.AllowReq{
+			Object: ObjectReport,
+			Action: ActionRead,
+		})
+		So(allow.Allow, ShouldBeFalse)
+	})
+}
+
+func TestAllowLogic_Allow_BuiltinOnly(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
+
+	for _, p := range []config.PolicyConf{
+		{
+			Engine: config.EngineRego,
+			Rego: config.RegoConf{
+				Path:  "../../etc/authorization.rego",
+				Query: "data.authorization.allow",
+			},
+		},
+		{
+			Engine: config.EngineCasbin,
+			Casbin: config.CasbinConf{
+				Model:  "../../etc/casbin_model.conf",
+				Policy: "../../etc/casbin_policy.csv",
+			},
+		},
+	} {
+		svcCtx := &svc.ServiceContext{
+			Config:        config.Config{},
+			CollectionRPC: mock.NewMockCollectionRpc(ctrl),
+			MomentRPC:     mock.NewMockMomentRpc(ctrl),
+			SystemRPC:     mockSystemRpc,
+			CommentRPC:    mock.NewMockCommentRpc(ctrl),
+			PostRPC:       mock.NewMockPostRpc(ctrl),
+			Policy:        svc.MustNewPolicy(p),
+		}
+		svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+		l := NewAllowLogic(context.Background(), svcCtx)
+
+		Convey("用户角色和举报始终使用内置策略: "+p.Engine, t, func() {
+			mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{}, nil)
+			allow, _ := l.Allow(&pb2.AllowReq{
+				UserId:   "UserId",
+				Object:   ObjectRole,
+				ObjectId: "AnotherUserId",
+				Action:   ActionRead,
+			})
+			So(allow.Allow, ShouldBeFalse)
+
+			mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{}, nil)
+			allow, _ = l.Allow(&pb2.AllowReq{
+				UserId: "UserId",
+				Object: ObjectReport,
+				Action: ActionRead,
+			})
+			So(allow.Allow, ShouldBeFalse)
+		})
+	}
+}
+
+func TestAllowLogic_Allow_CreateCommentReadable(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
+	mockMomentRpc := mock.NewMockMomentRpc(ctrl)
+	mockPostRpc := mock.NewMockPostRpc(ctrl)
+	mockBlockModel := mock.NewMockBlockModel(ctrl)
+	mockVisibilityModel := mock.NewMockVisibilityModel(ctrl)
+	mockMemberModel := mock.NewMockMemberModel(ctrl)
+
+	svcCtx := &svc.ServiceContext{
+		Config:          config.Config{},
+		CollectionRPC:   mock.NewMockCollectionRpc(ctrl),
+		MomentRPC:       mockMomentRpc,
+		SystemRPC:       mockSystemRpc,
+		CommentRPC:      mock.NewMockCommentRpc(ctrl),
+		PostRPC:         mockPostRpc,
+		BlockModel:      mockBlockModel,
+		VisibilityModel: mockVisibilityModel,
+		MemberModel:     mockMemberModel,
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+	l := NewAllowLogic(context.Background(), svcCtx)
+
+	comment := func(parentObject, parentId string) bool {
+		allow, _ := l.Allow(&pb2.AllowReq{
+			UserId:       "UserId",
+			Object:       ObjectComment,
+			Action:       ActionWrite,
+			ParentObject: parentObject,
+			ParentId:     parentId,
+		})
+		return allow.Allow
+	}
+
+	Convey("不能在隐藏的帖子下新建评论", t, func() {
+		svcCtx.Config.ContentStatus.Hidden = []int64{1}
+		defer func() {
+			svcCtx.Config.ContentStatus.Hidden = nil
+		}()
+		mockPostRpc.EXPECT().RetrievePost(Any(), Any()).Times(2).Return(&pb3.RetrievePostResp{
+			Post: &pb3.Post{
+				Id:     "PostId",
+				UserId: "PostUserId",
+				Status: 1,
+			},
+		}, nil)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{}, nil)
+		So(comment(ObjectPost, "PostId"), ShouldBeFalse)
+	})
+
+	Convey("只有成员能在私有社区的动态下新建评论", t, func() {
+		svcCtx.Config.PrivateCommunity = true
+		defer func() {
+			svcCtx.Config.PrivateCommunity = false
+		}()
+		expectMoment := func() {
+			mockMomentRpc.EXPECT().RetrieveMoment(Any(), Any()).Times(2).Return(&pb5.RetrieveMomentResp{
+				Moment: &pb5.Moment{
+					Id:          "MomentId",
+					UserId:      "MomentUserId",
+					CommunityId: "SecretId",
+				},
+			}, nil)
+			mockVisibilityModel.EXPECT().FindOneByCommunityId(Any(), "SecretId").Return(&model.Visibility{Private: true}, nil)
+		}
+
+		expectMoment()
+		mockMemberModel.EXPECT().FindOneByUserIdAndCommunityId(Any(), "UserId", "SecretId").Return(nil, model.ErrNotFound)
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{}, nil)
+		So(comment(ObjectMoment, "MomentId"), ShouldBeFalse)
+
+		expectMoment()
+		mockMemberModel.EXPECT().FindOneByUserIdAndCommunityId(Any(), "UserId", "SecretId").Return(&model.Member{}, nil)
+		mockBlockModel.EXPECT().FindOneByUserIdAndBlockedUserId(Any(), "MomentUserId", "UserId").Return(nil, model.ErrNotFound)
+		So(comment(ObjectMoment, "MomentId"), ShouldBeTrue)
+	})
+}
@@ -0,0 +1,28 @@
+package logic
+
+import (
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+)
+
+// 匿名用户权限
+//  依据 Anonymous 配置判定，不查询用户角色；未配置时允许读用户角色和举报以外的所有对象、不允许写
+//  允许读时仍受私有社区、内容状态和用户资料可见性的读限制，这些限制需要查询对象
+func (l *AllowLogic) allowAnonymous(in *pb.AllowReq) bool {
+	rules := l.svcCtx.Config.Anonymous.Rules
+	if len(rules) == 0 {
+		return in.Action == ActionRead && in.Object != ObjectRole && in.Object != ObjectReport
+	}
+
+	for _, r := range rules {
+		if r.Object != in.Object {
+			continue
+		}
+		for _, action := range r.Actions {
+			if action == in.Action {
+				return true
+			}
+		}
+	}
+	return false
+}
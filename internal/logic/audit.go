@@ -0,0 +1,26 @@
+package logic
+
+import (
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 记录每次判定的审计日志
+//  调用方由认证拦截器写入上下文的日志字段，会随日志一起输出，修改用户角色时记录授予或撤销的角色
+func (l *AllowLogic) audit(in *pb.AllowReq, allow bool) {
+	fields := []logx.LogField{
+		logx.Field("userId", in.UserId),
+		logx.Field("object", in.Object),
+		logx.Field("objectId", in.ObjectId),
+		logx.Field("action", in.Action),
+		logx.Field("allow", allow),
+		logx.Field("degraded", l.degraded),
+	}
+	if role := in.GetRole(); role != nil {
+		fields = append(fields,
+			logx.Field("role", role.Type),
+			logx.Field("roleCommunityId", role.CommunityId),
+		)
+	}
+	l.Infow("authorization decision", fields...)
+}
@@ -0,0 +1,50 @@
+package logic
+
+import (
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+)
+
+// 新建评论的权限
+//  从属对象必须是存在的、有内置策略的对象或其下的评论，用户能读回复链顶端的对象，且没有被其发布者拉黑
+func (l *AllowLogic) allowCreateComment(in *pb.AllowReq) bool {
+	object, id := l.commentRoot(in.ParentObject, in.ParentId)
+	if _, ok := policies[object]; !ok {
+		return false
+	}
+
+	res := l.resolve(object, id)
+	if res == nil || !l.allowReadTarget(in.UserId, object, id) {
+		return false
+	}
+	return !l.blockedBy(res.OwnerId, in.UserId)
+}
+
+// 判断用户是否被拉黑，查询失败时按拉黑处理
+func (l *AllowLogic) blockedBy(ownerId, userId string) bool {
+	if ownerId == "" || ownerId == userId {
+		return false
+	}
+
+	ctx, end := l.lookup(upstreamMongo, "FindOneByUserIdAndBlockedUserId")
+	_, err := l.svcCtx.BlockModel.FindOneByUserIdAndBlockedUserId(ctx, ownerId, userId)
+	end(err)
+	switch err {
+	case nil:
+		return true
+	case model.ErrNotFound:
+		return false
+	default:
+		l.Errorf("[blockedBy] find block failed, err: %v", err)
+		l.degraded = true
+		return true
+	}
+}
+
+// 查询对象的发布者，对象不存在或没有发布者时返回空
+func (l *AllowLogic) resolveOwnerId(object, id string) string {
+	if res := l.resolve(object, id); res != nil {
+		return res.OwnerId
+	}
+	return ""
+}
@@ -0,0 +1,41 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type BlockLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewBlockLogic(ctx context.Context, svcCtx *svc.ServiceContext) *BlockLogic {
+	return &BlockLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *BlockLogic) Block(in *pb.BlockReq) (*pb.BlockResp, error) {
+	if in.UserId == "" || in.BlockedUserId == "" {
+		return nil, errorx.ErrInvalidArgs
+	}
+	if in.UserId == in.BlockedUserId {
+		return nil, errorx.ErrBlockSelf
+	}
+
+	// 重复拉黑时不会插入
+	err := l.svcCtx.BlockModel.UpsertByUserIdAndBlockedUserId(l.ctx, in.UserId, in.BlockedUserId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BlockResp{}, nil
+}
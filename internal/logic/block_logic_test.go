@@ -0,0 +1,81 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/logic/mock"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	pb2 "github.com/xh-polaris/meowchat-authorization-rpc/pb"
+)
+
+func TestBlockLogic(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockBlockModel := mock.NewMockBlockModel(ctrl)
+	svcCtx := &svc.ServiceContext{BlockModel: mockBlockModel}
+	ctx := context.Background()
+
+	Convey("拉黑", t, func() {
+		block := NewBlockLogic(ctx, svcCtx)
+
+		_, err := block.Block(&pb2.BlockReq{UserId: "UserId"})
+		So(err, ShouldEqual, errorx.ErrInvalidArgs)
+		_, err = block.Block(&pb2.BlockReq{UserId: "UserId", BlockedUserId: "UserId"})
+		So(err, ShouldEqual, errorx.ErrBlockSelf)
+
+		mockBlockModel.EXPECT().UpsertByUserIdAndBlockedUserId(Any(), "UserId", "BlockedUserId").Return(nil)
+		_, err = block.Block(&pb2.BlockReq{UserId: "UserId", BlockedUserId: "BlockedUserId"})
+		So(err, ShouldBeNil)
+	})
+
+	Convey("取消拉黑", t, func() {
+		unblock := NewUnblockLogic(ctx, svcCtx)
+
+		_, err := unblock.Unblock(&pb2.UnblockReq{BlockedUserId: "BlockedUserId"})
+		So(err, ShouldEqual, errorx.ErrInvalidArgs)
+
+		// 删除所有相同的拉黑记录
+		mockBlockModel.EXPECT().DeleteByUserIdAndBlockedUserId(Any(), "UserId", "BlockedUserId").Return(int64(2), nil)
+		_, err = unblock.Unblock(&pb2.UnblockReq{UserId: "UserId", BlockedUserId: "BlockedUserId"})
+		So(err, ShouldBeNil)
+	})
+
+	Convey("查询拉黑列表", t, func() {
+		list := NewListBlockLogic(ctx, svcCtx)
+
+		_, err := list.ListBlock(&pb2.ListBlockReq{Count: 10})
+		So(err, ShouldEqual, errorx.ErrInvalidArgs)
+		_, err = list.ListBlock(&pb2.ListBlockReq{UserId: "UserId", Count: -1})
+		So(err, ShouldEqual, errorx.ErrInvalidArgs)
+		_, err = list.ListBlock(&pb2.ListBlockReq{UserId: "UserId", Skip: -1})
+		So(err, ShouldEqual, errorx.ErrInvalidArgs)
+
+		mockBlockModel.EXPECT().FindManyByUserId(Any(), "UserId", int64(5), int64(10)).Return([]*model.Block{
+			{BlockedUserId: "A"},
+			{BlockedUserId: "B"},
+		}, int64(7), nil)
+		resp, err := list.ListBlock(&pb2.ListBlockReq{UserId: "UserId", Skip: 5, Count: 10})
+		So(err, ShouldBeNil)
+		So(resp.BlockedUserIds, ShouldResemble, []string{"A", "B"})
+		So(resp.Total, ShouldEqual, 7)
+
+		// count 为 0 时使用默认值，超过上限时截断
+		mockBlockModel.EXPECT().FindManyByUserId(Any(), "UserId", int64(0), int64(defaultBlockCount)).Return(nil, int64(0), nil)
+		_, err = list.ListBlock(&pb2.ListBlockReq{UserId: "UserId"})
+		So(err, ShouldBeNil)
+		mockBlockModel.EXPECT().FindManyByUserId(Any(), "UserId", int64(0), int64(maxBlockCount)).Return(nil, int64(0), nil)
+		_, err = list.ListBlock(&pb2.ListBlockReq{UserId: "UserId", Count: 1000})
+		So(err, ShouldBeNil)
+
+		mockBlockModel.EXPECT().FindManyByUserId(Any(), Any(), Any(), Any()).Return(nil, int64(0), errors.New("mongo"))
+		_, err = list.ListBlock(&pb2.ListBlockReq{UserId: "UserId"})
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,82 @@
+package logic
+
+import (
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	. "github.com/xh-polaris/meowchat-system-rpc/constant"
+)
+
+// casbin 中的内置角色
+const (
+	// 所有用户都拥有的角色
+	casbinRoleAnyone = "anyone"
+	// 对象发布者
+	casbinRoleOwner = "owner"
+	// 不限定社区的域
+	casbinAnyDomain = "*"
+)
+
+// 使用 casbin 判定
+//  域为对象所属的社区，用户的角色来自 system-rpc 和本服务的授予
+func (l *AllowLogic) allowCasbin(p *svc.Policy, in *pb.AllowReq) bool {
+	res := l.resolveResource(in.Object, in.ObjectId)
+	if res == nil && in.Action != ActionRead {
+		return false
+	}
+
+	roles := l.casbinRoles(in.UserId)
+	if res != nil && res.OwnerId != "" && res.OwnerId == in.UserId {
+		roles = append(roles, svc.CasbinRole{Role: casbinRoleOwner, Domain: casbinAnyDomain})
+	}
+
+	domains := res.communityIds()
+	if len(domains) == 0 {
+		domains = []string{""}
+	}
+	ok, err := p.Casbin.Enforce(roles, domains, in.Object, in.Action)
+	if err != nil {
+		l.Errorf("[allowCasbin] enforce failed, err: %v", err)
+		return false
+	}
+	return ok
+}
+
+// 查询用户的所有角色及其所在的域
+func (l *AllowLogic) casbinRoles(userId string) []svc.CasbinRole {
+	roles := []svc.CasbinRole{{Role: casbinRoleAnyone, Domain: casbinAnyDomain}}
+
+	for _, r := range l.resolveRoles(userId) {
+		domain := casbinAnyDomain
+		if r.Type == RoleCommunityAdmin {
+			domain = r.CommunityId
+		}
+		roles = append(roles, svc.CasbinRole{Role: r.Type, Domain: domain})
+	}
+
+	ctx, end := l.lookup(upstreamMongo, "ListByUserId")
+	grants, err := l.svcCtx.GrantModel.ListByUserId(ctx, userId)
+	end(err)
+	if err != nil {
+		l.Errorf("[allowCasbin] list grants failed, err: %v", err)
+		l.degraded = true
+	}
+	for _, g := range grants {
+		domain := g.CommunityId
+		if domain == "" {
+			domain = casbinAnyDomain
+		}
+		roles = append(roles, svc.CasbinRole{Role: g.Role, Domain: domain})
+	}
+
+	// system-rpc 和授予中可能有相同的角色，去重以减少判定次数
+	seen := make(map[svc.CasbinRole]bool, len(roles))
+	unique := roles[:0]
+	for _, r := range roles {
+		if !seen[r] {
+			seen[r] = true
+			unique = append(unique, r)
+		}
+	}
+	return unique
+}
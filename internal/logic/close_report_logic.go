@@ -0,0 +1,40 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CloseReportLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewCloseReportLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CloseReportLogic {
+	return &CloseReportLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *CloseReportLogic) CloseReport(in *pb.CloseReportReq) (*pb.CloseReportResp, error) {
+	if in.UserId == "" || in.Object == "" || in.ObjectId == "" {
+		return nil, errorx.ErrInvalidArgs
+	}
+	if !NewAllowLogic(l.ctx, l.svcCtx).allowModerate(in.UserId, in.Object, in.ObjectId) {
+		return nil, errorx.ErrPermissionDenied
+	}
+
+	// 没有未处理的举报时不做任何修改
+	if _, err := l.svcCtx.ReportModel.CloseByObject(l.ctx, in.Object, in.ObjectId); err != nil {
+		return nil, err
+	}
+	return &pb.CloseReportResp{}, nil
+}
@@ -0,0 +1,25 @@
+package logic
+
+import (
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+)
+
+// 回复链的最大长度，超过时按评论不存在处理，避免评论互相回复时无限查询
+const maxCommentDepth = 16
+
+// 查询评论所在的对象，回复的从属对象是被回复的评论，沿回复链向上查找
+//  返回的对象不是评论，回复链中的评论不存在或回复链过长时返回空
+func (l *AllowLogic) commentRoot(object, id string) (string, string) {
+	for depth := 0; object == ObjectComment; depth++ {
+		if depth == maxCommentDepth {
+			l.Errorf("[commentRoot] reply chain of comment %s is too deep", id)
+			return "", ""
+		}
+		res := l.resolve(ObjectComment, id)
+		if res == nil {
+			return "", ""
+		}
+		object, id = res.ParentType, res.ParentId
+	}
+	return object, id
+}
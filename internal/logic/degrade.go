@@ -0,0 +1,82 @@
+package logic
+
+import (
+	"strconv"
+	"strings"
+
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	. "github.com/xh-polaris/meowchat-system-rpc/constant"
+	"github.com/zeromicro/go-zero/core/metric"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var degradedDecisions = metric.NewCounterVec(&metric.CounterVecOpts{
+	Namespace: "authorization",
+	Subsystem: "degraded",
+	Name:      "decisions_total",
+	Help:      "authorization decisions made while an upstream service or store was unavailable.",
+	Labels:    []string{"object", "action", "mode", "allow"},
+})
+
+// 记录上游服务的错误，只有服务不可用才影响判定，对象不存在等错误按原逻辑处理
+//  返回上游服务是否不可用
+func (l *AllowLogic) observe(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		l.Errorf("[observe] upstream unavailable, err: %v", err)
+		l.degraded = true
+		return true
+	default:
+		return false
+	}
+}
+
+// 按降级方式重新得到判定结果
+//  内置策略允许超级管理员写所有对象，能确认是超级管理员时仍然允许，以便在故障期间处理违规内容
+func (l *AllowLogic) degrade(in *pb.AllowReq) bool {
+	if in.Action == ActionWrite && l.builtin(in.Object) && l.containsRole(in.UserId, RoleSuperAdmin) {
+		degradedDecisions.Inc(in.Object, in.Action, RoleSuperAdmin, "true")
+		return true
+	}
+
+	mode := l.svcCtx.Config.Degrade.ModeOf(in.Object, in.Action)
+	var allow bool
+	switch mode {
+	case config.DegradeOpen:
+		allow = in.Action == ActionRead
+	case config.DegradeStale:
+		cache := CacheMiss
+		if l.svcCtx.Decisions != nil {
+			if v, ok := l.svcCtx.Decisions.Get(decisionKey(in)); ok {
+				allow = v.(bool)
+				cache = CacheHit
+			}
+		}
+		trace.SpanFromContext(l.ctx).SetAttributes(attribute.String(TraceCache, cache))
+	}
+
+	degradedDecisions.Inc(in.Object, in.Action, mode, strconv.FormatBool(allow))
+	return allow
+}
+
+// 保留正常判定的结果，供 stale 降级使用
+func (l *AllowLogic) remember(in *pb.AllowReq, allow bool) {
+	if l.svcCtx.Decisions != nil {
+		l.svcCtx.Decisions.Set(decisionKey(in), allow)
+	}
+}
+
+func decisionKey(in *pb.AllowReq) string {
+	return strings.Join([]string{in.UserId, in.Object, in.ObjectId, in.Action, in.ParentObject, in.ParentId,
+		in.GetRole().GetType(), in.GetRole().GetCommunityId()}, "\x00")
+}
+
+func (l *AllowLogic) builtin(object string) bool {
+	p := l.svcCtx.Policy
+	return !p.Uses(config.EngineRego, object) && !p.Uses(config.EngineCasbin, object)
+}
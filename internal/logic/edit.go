@@ -0,0 +1,75 @@
+package logic
+
+import (
+	"time"
+
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	. "github.com/xh-polaris/meowchat-system-rpc/constant"
+)
+
+// 发布者受修改期限和锁定限制的对象类型
+var ownerEditObjects = map[string]bool{
+	ObjectPost:    true,
+	ObjectMoment:  true,
+	ObjectComment: true,
+}
+
+// 判断对象类型是否限制发布者修改，只有这些对象可以锁定
+//  没有内置策略但注册了解析器的对象使用通用策略，同样受限制
+func (l *AllowLogic) limitsOwnerEdit(object string) bool {
+	if ownerEditObjects[object] {
+		return true
+	}
+	_, ok := l.svcCtx.Resolvers.Get(object)
+	return policies[object] == nil && ok
+}
+
+// 判断发布者能否写对象，在策略判定之后对所有引擎生效
+//  发布者受修改期限和锁定限制，能锁定对象的用户不受限制
+func (l *AllowLogic) allowOwnerWrite(in *pb.AllowReq) bool {
+	if in.Action != ActionWrite || in.ObjectId == "" || !l.limitsOwnerEdit(in.Object) {
+		return true
+	}
+
+	res := l.resolve(in.Object, in.ObjectId)
+	if res == nil || res.OwnerId == "" || res.OwnerId != in.UserId {
+		return true
+	}
+	return l.allowOwnerEdit(in.Object, in.ObjectId, res.CreateAt) || l.allowModerate(in.UserId, in.Object, in.ObjectId)
+}
+
+// 判断发布者是否还能修改对象
+//  超过配置的修改期限或对象被锁定后不能修改，查询锁定失败时按锁定处理
+func (l *AllowLogic) allowOwnerEdit(object, id string, createAt int64) bool {
+	for _, w := range l.svcCtx.Config.EditWindows {
+		if w.Object == object && time.Since(time.Unix(createAt, 0)) > w.Duration {
+			return false
+		}
+	}
+
+	ctx, end := l.lookup(upstreamMongo, "FindOneByObjectAndObjectId")
+	_, err := l.svcCtx.LockModel.FindOneByObjectAndObjectId(ctx, object, id)
+	end(err)
+	switch err {
+	case nil:
+		return false
+	case model.ErrNotFound:
+		return true
+	default:
+		l.Errorf("[allowOwnerEdit] find lock failed, err: %v", err)
+		l.degraded = true
+		return false
+	}
+}
+
+// 判断用户能否锁定或解锁对象、读和处理对象的举报、管理私有社区的可见性和成员
+//  允许超级管理员、对象所属社区的管理员，不属于社区的对象如帖子只允许超级管理员
+func (l *AllowLogic) allowModerate(userId, object, id string) bool {
+	communityId := l.resolveCommunityId(object, id)
+	if communityId == "" {
+		return l.containsRole(userId, RoleSuperAdmin)
+	}
+	return l.allowCommunityOrSuperAdmin(userId, communityId)
+}
@@ -0,0 +1,48 @@
+package logic
+
+import (
+	"context"
+
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type FollowLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewFollowLogic(ctx context.Context, svcCtx *svc.ServiceContext) *FollowLogic {
+	return &FollowLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *FollowLogic) Follow(in *pb.FollowReq) (*pb.FollowResp, error) {
+	if in.UserId == "" || in.FollowedUserId == "" {
+		return nil, errorx.ErrInvalidArgs
+	}
+	if !NewAllowLogic(l.ctx, l.svcCtx).allowFollow(&pb.AllowReq{
+		UserId:       in.UserId,
+		Object:       ObjectFollow,
+		Action:       ActionWrite,
+		ParentObject: ObjectUser,
+		ParentId:     in.FollowedUserId,
+	}) {
+		return nil, errorx.ErrPermissionDenied
+	}
+
+	// 重复关注时不会插入
+	err := l.svcCtx.FollowModel.UpsertByUserIdAndFollowedUserId(l.ctx, in.UserId, in.FollowedUserId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.FollowResp{}, nil
+}
@@ -0,0 +1,58 @@
+package logic
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/logic/mock"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	pb2 "github.com/xh-polaris/meowchat-authorization-rpc/pb"
+)
+
+func TestFollowLogic(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockBlockModel := mock.NewMockBlockModel(ctrl)
+	mockFollowModel := mock.NewMockFollowModel(ctrl)
+	svcCtx := &svc.ServiceContext{
+		BlockModel:  mockBlockModel,
+		FollowModel: mockFollowModel,
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+	follow := NewFollowLogic(context.Background(), svcCtx)
+	unfollow := NewUnfollowLogic(context.Background(), svcCtx)
+
+	Convey("参数不合法时报错", t, func() {
+		_, err := follow.Follow(&pb2.FollowReq{UserId: "UserId"})
+		So(err, ShouldEqual, errorx.ErrInvalidArgs)
+		_, err = unfollow.Unfollow(&pb2.UnfollowReq{FollowedUserId: "AnotherUserId"})
+		So(err, ShouldEqual, errorx.ErrInvalidArgs)
+	})
+
+	Convey("关注", t, func() {
+		_, err := follow.Follow(&pb2.FollowReq{UserId: "UserId", FollowedUserId: "UserId"})
+		So(err, ShouldEqual, errorx.ErrPermissionDenied)
+
+		mockBlockModel.EXPECT().FindOneByUserIdAndBlockedUserId(Any(), "AnotherUserId", "UserId").Return(&model.Block{}, nil)
+		_, err = follow.Follow(&pb2.FollowReq{UserId: "UserId", FollowedUserId: "AnotherUserId"})
+		So(err, ShouldEqual, errorx.ErrPermissionDenied)
+
+		mockBlockModel.EXPECT().FindOneByUserIdAndBlockedUserId(Any(), "AnotherUserId", "UserId").Return(nil, model.ErrNotFound)
+		mockBlockModel.EXPECT().FindOneByUserIdAndBlockedUserId(Any(), "UserId", "AnotherUserId").Return(nil, model.ErrNotFound)
+		mockFollowModel.EXPECT().UpsertByUserIdAndFollowedUserId(Any(), "UserId", "AnotherUserId").Return(nil)
+		_, err = follow.Follow(&pb2.FollowReq{UserId: "UserId", FollowedUserId: "AnotherUserId"})
+		So(err, ShouldBeNil)
+	})
+
+	Convey("取消关注", t, func() {
+		// 删除所有相同的关注记录
+		mockFollowModel.EXPECT().DeleteByUserIdAndFollowedUserId(Any(), "UserId", "AnotherUserId").Return(int64(2), nil)
+		_, err := unfollow.Unfollow(&pb2.UnfollowReq{UserId: "UserId", FollowedUserId: "AnotherUserId"})
+		So(err, ShouldBeNil)
+	})
+}
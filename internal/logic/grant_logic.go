@@ -0,0 +1,48 @@
+package logic
+
+import (
+	"context"
+
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GrantLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGrantLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GrantLogic {
+	return &GrantLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *GrantLogic) Grant(in *pb.GrantReq) (*pb.GrantResp, error) {
+	if in.UserId == "" || in.GranteeId == "" || in.GetRole().GetType() == "" {
+		return nil, errorx.ErrInvalidArgs
+	}
+	if !NewAllowLogic(l.ctx, l.svcCtx).allowRole(&pb.AllowReq{
+		UserId:   in.UserId,
+		Object:   ObjectRole,
+		ObjectId: in.GranteeId,
+		Action:   ActionWrite,
+		Role:     in.Role,
+	}) {
+		return nil, errorx.ErrPermissionDenied
+	}
+
+	// 重复授予时不会插入
+	err := l.svcCtx.GrantModel.UpsertByUserIdAndRole(l.ctx, in.GranteeId, in.Role.Type, in.Role.CommunityId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GrantResp{}, nil
+}
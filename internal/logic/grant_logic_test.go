@@ -0,0 +1,81 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/logic/mock"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	pb2 "github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	. "github.com/xh-polaris/meowchat-system-rpc/constant"
+	"github.com/xh-polaris/meowchat-system-rpc/pb"
+)
+
+func TestGrantLogic(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
+	mockGrantModel := mock.NewMockGrantModel(ctrl)
+	svcCtx := &svc.ServiceContext{
+		SystemRPC:  mockSystemRpc,
+		GrantModel: mockGrantModel,
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+	grant := NewGrantLogic(context.Background(), svcCtx)
+	revoke := NewRevokeLogic(context.Background(), svcCtx)
+
+	expectRoles := func(roles ...*pb.Role) {
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{Roles: roles}, nil)
+	}
+	communityAdmin := &pb.Role{Type: RoleCommunityAdmin, CommunityId: "CommunityId"}
+	superAdmin := &pb.Role{Type: RoleSuperAdmin}
+	moderator := &pb2.Role{Type: "moderator"}
+	communityModerator := &pb2.Role{Type: RoleCommunityAdmin, CommunityId: "CommunityId"}
+
+	Convey("参数不合法时报错", t, func() {
+		_, err := grant.Grant(&pb2.GrantReq{UserId: "AdminId", GranteeId: "UserId"})
+		So(err, ShouldEqual, errorx.ErrInvalidArgs)
+		_, err = revoke.Revoke(&pb2.RevokeReq{GranteeId: "UserId", Role: moderator})
+		So(err, ShouldEqual, errorx.ErrInvalidArgs)
+	})
+
+	Convey("社区管理员只能授予本社区的社区管理员角色", t, func() {
+		expectRoles(communityAdmin)
+		_, err := grant.Grant(&pb2.GrantReq{UserId: "AdminId", GranteeId: "UserId", Role: moderator})
+		So(err, ShouldEqual, errorx.ErrPermissionDenied)
+
+		expectRoles(communityAdmin)
+		mockGrantModel.EXPECT().UpsertByUserIdAndRole(Any(), "UserId", RoleCommunityAdmin, "CommunityId").Return(nil)
+		_, err = grant.Grant(&pb2.GrantReq{UserId: "AdminId", GranteeId: "UserId", Role: communityModerator})
+		So(err, ShouldBeNil)
+	})
+
+	Convey("超级管理员授予其他角色", t, func() {
+		expectRoles(superAdmin)
+		mockGrantModel.EXPECT().UpsertByUserIdAndRole(Any(), "UserId", "moderator", "").Return(nil)
+		_, err := grant.Grant(&pb2.GrantReq{UserId: "SuperAdminId", GranteeId: "UserId", Role: moderator})
+		So(err, ShouldBeNil)
+
+		expectRoles(superAdmin)
+		mockGrantModel.EXPECT().UpsertByUserIdAndRole(Any(), "UserId", "moderator", "").Return(errors.New("mongo unavailable"))
+		_, err = grant.Grant(&pb2.GrantReq{UserId: "SuperAdminId", GranteeId: "UserId", Role: moderator})
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("撤销授予", t, func() {
+		expectRoles()
+		_, err := revoke.Revoke(&pb2.RevokeReq{UserId: "UserId", GranteeId: "UserId", Role: moderator})
+		So(err, ShouldEqual, errorx.ErrPermissionDenied)
+
+		// 删除所有相同的授予
+		expectRoles(superAdmin)
+		mockGrantModel.EXPECT().DeleteByUserIdAndRole(Any(), "UserId", "moderator", "").Return(int64(2), nil)
+		_, err = revoke.Revoke(&pb2.RevokeReq{UserId: "SuperAdminId", GranteeId: "UserId", Role: moderator})
+		So(err, ShouldBeNil)
+	})
+}
@@ -0,0 +1,86 @@
+package logic
+
+import (
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/resolver"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+)
+
+// 点赞、关注和举报的写都是新建，parentObject 和 parentId 为被点赞、关注或举报的对象
+//  取消点赞、取消关注只涉及用户自己的记录，不需要判定
+
+// 点赞权限
+//  允许读，允许用户给存在且可读的对象点赞，按配置不允许给自己发布的内容点赞
+func (l *AllowLogic) allowLike(in *pb.AllowReq) bool {
+	if in.Action == ActionRead {
+		return true
+	}
+
+	res := l.interactionTarget(in)
+	if res == nil {
+		return false
+	}
+	return l.svcCtx.Config.Interaction.SelfLike(in.ParentObject) || res.OwnerId != in.UserId
+}
+
+// 关注权限
+//  允许读，允许用户关注其他用户，拉黑了对方或被对方拉黑时不能关注
+func (l *AllowLogic) allowFollow(in *pb.AllowReq) bool {
+	if in.Action == ActionRead {
+		return true
+	}
+	if in.ParentObject != ObjectUser || in.ParentId == in.UserId || l.interactionTarget(in) == nil {
+		return false
+	}
+
+	return !l.blockedBy(in.ParentId, in.UserId) && !l.blockedBy(in.UserId, in.ParentId)
+}
+
+// 举报权限
+//  允许超级管理员、被举报对象所属社区的管理员读
+//  允许用户举报存在且可读的对象，不能举报自己发布的内容，同一对象未处理的举报只能有一个
+func (l *AllowLogic) allowReport(in *pb.AllowReq) bool {
+	if in.Action == ActionRead {
+		return l.allowModerate(in.UserId, in.ParentObject, in.ParentId)
+	}
+	return l.allowReportTarget(in) && !l.reported(in.UserId, in.ParentObject, in.ParentId)
+}
+
+// 判断用户能否举报对象，不考虑已有的举报
+func (l *AllowLogic) allowReportTarget(in *pb.AllowReq) bool {
+	res := l.interactionTarget(in)
+	return res != nil && res.OwnerId != in.UserId
+}
+
+// 查询互动的对象，对象不存在或用户不能读时返回nil
+func (l *AllowLogic) interactionTarget(in *pb.AllowReq) *resolver.Resource {
+	if in.ParentId == "" {
+		return nil
+	}
+	res := l.resolve(in.ParentObject, in.ParentId)
+	if res == nil {
+		return nil
+	}
+	if !l.allowReadTarget(in.UserId, in.ParentObject, in.ParentId) {
+		return nil
+	}
+	return res
+}
+
+// 判断用户是否有未处理的对该对象的举报，查询失败时按已举报处理
+func (l *AllowLogic) reported(userId, object, objectId string) bool {
+	ctx, end := l.lookup(upstreamMongo, "FindOneOpenByUserIdAndObject")
+	_, err := l.svcCtx.ReportModel.FindOneOpenByUserIdAndObject(ctx, userId, object, objectId)
+	end(err)
+	switch err {
+	case nil:
+		return true
+	case model.ErrNotFound:
+		return false
+	default:
+		l.Errorf("[reported] find report failed, err: %v", err)
+		l.degraded = true
+		return true
+	}
+}
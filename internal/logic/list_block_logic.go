@@ -0,0 +1,54 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 分页查询拉黑列表时每页的默认和最大数量
+const (
+	defaultBlockCount = 20
+	maxBlockCount     = 100
+)
+
+type ListBlockLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewListBlockLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListBlockLogic {
+	return &ListBlockLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *ListBlockLogic) ListBlock(in *pb.ListBlockReq) (*pb.ListBlockResp, error) {
+	if in.UserId == "" || in.Skip < 0 || in.Count < 0 {
+		return nil, errorx.ErrInvalidArgs
+	}
+	count := in.Count
+	switch {
+	case count == 0:
+		count = defaultBlockCount
+	case count > maxBlockCount:
+		count = maxBlockCount
+	}
+
+	data, total, err := l.svcCtx.BlockModel.FindManyByUserId(l.ctx, in.UserId, in.Skip, count)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]string, 0, len(data))
+	for _, val := range data {
+		res = append(res, val.BlockedUserId)
+	}
+	return &pb.ListBlockResp{BlockedUserIds: res, Total: total}, nil
+}
@@ -0,0 +1,56 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type LockLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewLockLogic(ctx context.Context, svcCtx *svc.ServiceContext) *LockLogic {
+	return &LockLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *LockLogic) Lock(in *pb.LockReq) (*pb.LockResp, error) {
+	allowLogic := NewAllowLogic(l.ctx, l.svcCtx)
+	// 只有限制发布者修改的对象才能锁定
+	if in.UserId == "" || in.Object == "" || in.ObjectId == "" || !allowLogic.limitsOwnerEdit(in.Object) {
+		return nil, errorx.ErrInvalidArgs
+	}
+	if !allowLogic.allowModerate(in.UserId, in.Object, in.ObjectId) {
+		return nil, errorx.ErrPermissionDenied
+	}
+
+	// 重复锁定时直接返回
+	_, err := l.svcCtx.LockModel.FindOneByObjectAndObjectId(l.ctx, in.Object, in.ObjectId)
+	switch err {
+	case nil:
+		return &pb.LockResp{}, nil
+	case model.ErrNotFound:
+	default:
+		return nil, err
+	}
+
+	err = l.svcCtx.LockModel.Insert(l.ctx, &model.Lock{
+		Object:   in.Object,
+		ObjectId: in.ObjectId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.LockResp{}, nil
+}
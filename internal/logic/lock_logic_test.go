@@ -0,0 +1,160 @@
+package logic
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/logic/mock"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	pb2 "github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	pb5 "github.com/xh-polaris/meowchat-moment-rpc/pb"
+	pb3 "github.com/xh-polaris/meowchat-post-rpc/pb"
+	. "github.com/xh-polaris/meowchat-system-rpc/constant"
+	"github.com/xh-polaris/meowchat-system-rpc/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestLockLogic(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
+	mockMomentRpc := mock.NewMockMomentRpc(ctrl)
+	mockPostRpc := mock.NewMockPostRpc(ctrl)
+	mockLockModel := mock.NewMockLockModel(ctrl)
+
+	svcCtx := &svc.ServiceContext{
+		Config:        config.Config{},
+		CollectionRPC: mock.NewMockCollectionRpc(ctrl),
+		MomentRPC:     mockMomentRpc,
+		SystemRPC:     mockSystemRpc,
+		CommentRPC:    mock.NewMockCommentRpc(ctrl),
+		PostRPC:       mockPostRpc,
+		LockModel:     mockLockModel,
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+
+	expectMoment := func() {
+		mockMomentRpc.EXPECT().RetrieveMoment(Any(), Any()).Return(&pb5.RetrieveMomentResp{
+			Moment: &pb5.Moment{
+				Id:          "MomentId",
+				UserId:      "AuthorId",
+				CommunityId: "CommunityId",
+			},
+		}, nil)
+	}
+	expectPost := func() {
+		mockPostRpc.EXPECT().RetrievePost(Any(), Any()).Return(&pb3.RetrievePostResp{
+			Post: &pb3.Post{
+				Id:     "PostId",
+				UserId: "AuthorId",
+			},
+		}, nil)
+	}
+	expectRoles := func(roles ...*pb.Role) {
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{Roles: roles}, nil)
+	}
+	communityAdmin := &pb.Role{Type: RoleCommunityAdmin, CommunityId: "CommunityId"}
+	superAdmin := &pb.Role{Type: RoleSuperAdmin}
+
+	Convey("允许社区管理员锁定本社区的动态", t, func() {
+		expectMoment()
+		expectRoles(communityAdmin)
+		mockLockModel.EXPECT().FindOneByObjectAndObjectId(Any(), ObjectMoment, "MomentId").Return(nil, model.ErrNotFound)
+		mockLockModel.EXPECT().Insert(Any(), &model.Lock{Object: ObjectMoment, ObjectId: "MomentId"}).Return(nil)
+		_, err := NewLockLogic(context.Background(), svcCtx).Lock(&pb2.LockReq{
+			UserId:   "AdminId",
+			Object:   ObjectMoment,
+			ObjectId: "MomentId",
+		})
+		So(err, ShouldBeNil)
+	})
+
+	Convey("允许超级管理员锁定不属于社区的帖子", t, func() {
+		expectPost()
+		expectRoles(superAdmin)
+		mockLockModel.EXPECT().FindOneByObjectAndObjectId(Any(), ObjectPost, "PostId").Return(&model.Lock{}, nil)
+		_, err := NewLockLogic(context.Background(), svcCtx).Lock(&pb2.LockReq{
+			UserId:   "SuperAdminId",
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+		})
+		So(err, ShouldBeNil)
+	})
+
+	Convey("社区管理员不能锁定帖子", t, func() {
+		expectPost()
+		expectRoles(communityAdmin)
+		_, err := NewLockLogic(context.Background(), svcCtx).Lock(&pb2.LockReq{
+			UserId:   "AdminId",
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+		})
+		So(err, ShouldEqual, errorx.ErrPermissionDenied)
+	})
+
+	Convey("发布者不能解锁自己的动态", t, func() {
+		expectMoment()
+		expectRoles()
+		_, err := NewUnlockLogic(context.Background(), svcCtx).Unlock(&pb2.UnlockReq{
+			UserId:   "AuthorId",
+			Object:   ObjectMoment,
+			ObjectId: "MomentId",
+		})
+		So(err, ShouldEqual, errorx.ErrPermissionDenied)
+	})
+
+	Convey("允许社区管理员解锁本社区的动态", t, func() {
+		id := primitive.NewObjectID()
+		expectMoment()
+		expectRoles(communityAdmin)
+		mockLockModel.EXPECT().FindOneByObjectAndObjectId(Any(), ObjectMoment, "MomentId").Return(&model.Lock{ID: id}, nil)
+		mockLockModel.EXPECT().Delete(Any(), id.Hex()).Return(int64(1), nil)
+		_, err := NewUnlockLogic(context.Background(), svcCtx).Unlock(&pb2.UnlockReq{
+			UserId:   "AdminId",
+			Object:   ObjectMoment,
+			ObjectId: "MomentId",
+		})
+		So(err, ShouldBeNil)
+	})
+
+	Convey("允许超级管理员解锁帖子", t, func() {
+		expectPost()
+		expectRoles(superAdmin)
+		mockLockModel.EXPECT().FindOneByObjectAndObjectId(Any(), ObjectPost, "PostId").Return(nil, model.ErrNotFound)
+		_, err := NewUnlockLogic(context.Background(), svcCtx).Unlock(&pb2.UnlockReq{
+			UserId:   "SuperAdminId",
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+		})
+		So(err, ShouldBeNil)
+	})
+
+	Convey("不能锁定不限制发布者修改的对象", t, func() {
+		_, err := NewLockLogic(context.Background(), svcCtx).Lock(&pb2.LockReq{
+			UserId:   "SuperAdminId",
+			Object:   ObjectCat,
+			ObjectId: "CatId",
+		})
+		So(err, ShouldEqual, errorx.ErrInvalidArgs)
+	})
+
+	Convey("缺少操作的用户", t, func() {
+		_, err := NewLockLogic(context.Background(), svcCtx).Lock(&pb2.LockReq{
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+		})
+		So(err, ShouldEqual, errorx.ErrInvalidArgs)
+		_, err = NewUnlockLogic(context.Background(), svcCtx).Unlock(&pb2.UnlockReq{
+			Object:   ObjectPost,
+			ObjectId: "PostId",
+		})
+		So(err, ShouldEqual, errorx.ErrInvalidArgs)
+	})
+}
@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: block_model.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	mongo "go.mongodb.org/mongo-driver/mongo"
+)
+
+// MockBlockModel is a mock of BlockModel interface.
+type MockBlockModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockModelMockRecorder
+}
+
+// MockBlockModelMockRecorder is the mock recorder for MockBlockModel.
+type MockBlockModelMockRecorder struct {
+	mock *MockBlockModel
+}
+
+// NewMockBlockModel creates a new mock instance.
+func NewMockBlockModel(ctrl *gomock.Controller) *MockBlockModel {
+	mock := &MockBlockModel{ctrl: ctrl}
+	mock.recorder = &MockBlockModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockModel) EXPECT() *MockBlockModelMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockBlockModel) Delete(ctx context.Context, id string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBlockModelMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBlockModel)(nil).Delete), ctx, id)
+}
+
+// DeleteByUserIdAndBlockedUserId mocks base method.
+func (m *MockBlockModel) DeleteByUserIdAndBlockedUserId(ctx context.Context, userId, blockedUserId string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserIdAndBlockedUserId", ctx, userId, blockedUserId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUserIdAndBlockedUserId indicates an expected call of DeleteByUserIdAndBlockedUserId.
+func (mr *MockBlockModelMockRecorder) DeleteByUserIdAndBlockedUserId(ctx, userId, blockedUserId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserIdAndBlockedUserId", reflect.TypeOf((*MockBlockModel)(nil).DeleteByUserIdAndBlockedUserId), ctx, userId, blockedUserId)
+}
+
+// FindManyByUserId mocks base method.
+func (m *MockBlockModel) FindManyByUserId(ctx context.Context, userId string, skip, count int64) ([]*model.Block, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindManyByUserId", ctx, userId, skip, count)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindManyByUserId indicates an expected call of FindManyByUserId.
+func (mr *MockBlockModelMockRecorder) FindManyByUserId(ctx, userId, skip, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindManyByUserId", reflect.TypeOf((*MockBlockModel)(nil).FindManyByUserId), ctx, userId, skip, count)
+}
+
+// FindOne mocks base method.
+func (m *MockBlockModel) FindOne(ctx context.Context, id string) (*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOne", ctx, id)
+	ret0, _ := ret[0].(*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOne indicates an expected call of FindOne.
+func (mr *MockBlockModelMockRecorder) FindOne(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockBlockModel)(nil).FindOne), ctx, id)
+}
+
+// FindOneByUserIdAndBlockedUserId mocks base method.
+func (m *MockBlockModel) FindOneByUserIdAndBlockedUserId(ctx context.Context, userId, blockedUserId string) (*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByUserIdAndBlockedUserId", ctx, userId, blockedUserId)
+	ret0, _ := ret[0].(*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByUserIdAndBlockedUserId indicates an expected call of FindOneByUserIdAndBlockedUserId.
+func (mr *MockBlockModelMockRecorder) FindOneByUserIdAndBlockedUserId(ctx, userId, blockedUserId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByUserIdAndBlockedUserId", reflect.TypeOf((*MockBlockModel)(nil).FindOneByUserIdAndBlockedUserId), ctx, userId, blockedUserId)
+}
+
+// Insert mocks base method.
+func (m *MockBlockModel) Insert(ctx context.Context, data *model.Block) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockBlockModelMockRecorder) Insert(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockBlockModel)(nil).Insert), ctx, data)
+}
+
+// Update mocks base method.
+func (m *MockBlockModel) Update(ctx context.Context, data *model.Block) (*mongo.UpdateResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, data)
+	ret0, _ := ret[0].(*mongo.UpdateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBlockModelMockRecorder) Update(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBlockModel)(nil).Update), ctx, data)
+}
+
+// UpsertByUserIdAndBlockedUserId mocks base method.
+func (m *MockBlockModel) UpsertByUserIdAndBlockedUserId(ctx context.Context, userId, blockedUserId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertByUserIdAndBlockedUserId", ctx, userId, blockedUserId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertByUserIdAndBlockedUserId indicates an expected call of UpsertByUserIdAndBlockedUserId.
+func (mr *MockBlockModelMockRecorder) UpsertByUserIdAndBlockedUserId(ctx, userId, blockedUserId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertByUserIdAndBlockedUserId", reflect.TypeOf((*MockBlockModel)(nil).UpsertByUserIdAndBlockedUserId), ctx, userId, blockedUserId)
+}
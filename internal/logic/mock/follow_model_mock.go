@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: follow_model.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	mongo "go.mongodb.org/mongo-driver/mongo"
+)
+
+// MockFollowModel is a mock of FollowModel interface.
+type MockFollowModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockFollowModelMockRecorder
+}
+
+// MockFollowModelMockRecorder is the mock recorder for MockFollowModel.
+type MockFollowModelMockRecorder struct {
+	mock *MockFollowModel
+}
+
+// NewMockFollowModel creates a new mock instance.
+func NewMockFollowModel(ctrl *gomock.Controller) *MockFollowModel {
+	mock := &MockFollowModel{ctrl: ctrl}
+	mock.recorder = &MockFollowModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFollowModel) EXPECT() *MockFollowModelMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockFollowModel) Delete(ctx context.Context, id string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFollowModelMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFollowModel)(nil).Delete), ctx, id)
+}
+
+// DeleteByUserIdAndFollowedUserId mocks base method.
+func (m *MockFollowModel) DeleteByUserIdAndFollowedUserId(ctx context.Context, userId, followedUserId string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserIdAndFollowedUserId", ctx, userId, followedUserId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUserIdAndFollowedUserId indicates an expected call of DeleteByUserIdAndFollowedUserId.
+func (mr *MockFollowModelMockRecorder) DeleteByUserIdAndFollowedUserId(ctx, userId, followedUserId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserIdAndFollowedUserId", reflect.TypeOf((*MockFollowModel)(nil).DeleteByUserIdAndFollowedUserId), ctx, userId, followedUserId)
+}
+
+// FindOne mocks base method.
+func (m *MockFollowModel) FindOne(ctx context.Context, id string) (*model.Follow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOne", ctx, id)
+	ret0, _ := ret[0].(*model.Follow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOne indicates an expected call of FindOne.
+func (mr *MockFollowModelMockRecorder) FindOne(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockFollowModel)(nil).FindOne), ctx, id)
+}
+
+// FindOneByUserIdAndFollowedUserId mocks base method.
+func (m *MockFollowModel) FindOneByUserIdAndFollowedUserId(ctx context.Context, userId, followedUserId string) (*model.Follow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByUserIdAndFollowedUserId", ctx, userId, followedUserId)
+	ret0, _ := ret[0].(*model.Follow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByUserIdAndFollowedUserId indicates an expected call of FindOneByUserIdAndFollowedUserId.
+func (mr *MockFollowModelMockRecorder) FindOneByUserIdAndFollowedUserId(ctx, userId, followedUserId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByUserIdAndFollowedUserId", reflect.TypeOf((*MockFollowModel)(nil).FindOneByUserIdAndFollowedUserId), ctx, userId, followedUserId)
+}
+
+// Insert mocks base method.
+func (m *MockFollowModel) Insert(ctx context.Context, data *model.Follow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockFollowModelMockRecorder) Insert(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockFollowModel)(nil).Insert), ctx, data)
+}
+
+// Update mocks base method.
+func (m *MockFollowModel) Update(ctx context.Context, data *model.Follow) (*mongo.UpdateResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, data)
+	ret0, _ := ret[0].(*mongo.UpdateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockFollowModelMockRecorder) Update(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFollowModel)(nil).Update), ctx, data)
+}
+
+// UpsertByUserIdAndFollowedUserId mocks base method.
+func (m *MockFollowModel) UpsertByUserIdAndFollowedUserId(ctx context.Context, userId, followedUserId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertByUserIdAndFollowedUserId", ctx, userId, followedUserId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertByUserIdAndFollowedUserId indicates an expected call of UpsertByUserIdAndFollowedUserId.
+func (mr *MockFollowModelMockRecorder) UpsertByUserIdAndFollowedUserId(ctx, userId, followedUserId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertByUserIdAndFollowedUserId", reflect.TypeOf((*MockFollowModel)(nil).UpsertByUserIdAndFollowedUserId), ctx, userId, followedUserId)
+}
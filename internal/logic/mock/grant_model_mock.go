@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: grant_model.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	mongo "go.mongodb.org/mongo-driver/mongo"
+)
+
+// MockGrantModel is a mock of GrantModel interface.
+type MockGrantModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockGrantModelMockRecorder
+}
+
+// MockGrantModelMockRecorder is the mock recorder for MockGrantModel.
+type MockGrantModelMockRecorder struct {
+	mock *MockGrantModel
+}
+
+// NewMockGrantModel creates a new mock instance.
+func NewMockGrantModel(ctrl *gomock.Controller) *MockGrantModel {
+	mock := &MockGrantModel{ctrl: ctrl}
+	mock.recorder = &MockGrantModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGrantModel) EXPECT() *MockGrantModelMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockGrantModel) Delete(ctx context.Context, id string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockGrantModelMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGrantModel)(nil).Delete), ctx, id)
+}
+
+// DeleteByUserIdAndRole mocks base method.
+func (m *MockGrantModel) DeleteByUserIdAndRole(ctx context.Context, userId, role, communityId string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserIdAndRole", ctx, userId, role, communityId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUserIdAndRole indicates an expected call of DeleteByUserIdAndRole.
+func (mr *MockGrantModelMockRecorder) DeleteByUserIdAndRole(ctx, userId, role, communityId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserIdAndRole", reflect.TypeOf((*MockGrantModel)(nil).DeleteByUserIdAndRole), ctx, userId, role, communityId)
+}
+
+// FindOne mocks base method.
+func (m *MockGrantModel) FindOne(ctx context.Context, id string) (*model.Grant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOne", ctx, id)
+	ret0, _ := ret[0].(*model.Grant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOne indicates an expected call of FindOne.
+func (mr *MockGrantModelMockRecorder) FindOne(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockGrantModel)(nil).FindOne), ctx, id)
+}
+
+// Insert mocks base method.
+func (m *MockGrantModel) Insert(ctx context.Context, data *model.Grant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockGrantModelMockRecorder) Insert(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockGrantModel)(nil).Insert), ctx, data)
+}
+
+// ListByUserId mocks base method.
+func (m *MockGrantModel) ListByUserId(ctx context.Context, userId string) ([]*model.Grant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUserId", ctx, userId)
+	ret0, _ := ret[0].([]*model.Grant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUserId indicates an expected call of ListByUserId.
+func (mr *MockGrantModelMockRecorder) ListByUserId(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserId", reflect.TypeOf((*MockGrantModel)(nil).ListByUserId), ctx, userId)
+}
+
+// Update mocks base method.
+func (m *MockGrantModel) Update(ctx context.Context, data *model.Grant) (*mongo.UpdateResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, data)
+	ret0, _ := ret[0].(*mongo.UpdateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGrantModelMockRecorder) Update(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGrantModel)(nil).Update), ctx, data)
+}
+
+// UpsertByUserIdAndRole mocks base method.
+func (m *MockGrantModel) UpsertByUserIdAndRole(ctx context.Context, userId, role, communityId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertByUserIdAndRole", ctx, userId, role, communityId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertByUserIdAndRole indicates an expected call of UpsertByUserIdAndRole.
+func (mr *MockGrantModelMockRecorder) UpsertByUserIdAndRole(ctx, userId, role, communityId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertByUserIdAndRole", reflect.TypeOf((*MockGrantModel)(nil).UpsertByUserIdAndRole), ctx, userId, role, communityId)
+}
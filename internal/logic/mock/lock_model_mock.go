@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: lock_model.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	mongo "go.mongodb.org/mongo-driver/mongo"
+)
+
+// MockLockModel is a mock of LockModel interface.
+type MockLockModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockModelMockRecorder
+}
+
+// MockLockModelMockRecorder is the mock recorder for MockLockModel.
+type MockLockModelMockRecorder struct {
+	mock *MockLockModel
+}
+
+// NewMockLockModel creates a new mock instance.
+func NewMockLockModel(ctrl *gomock.Controller) *MockLockModel {
+	mock := &MockLockModel{ctrl: ctrl}
+	mock.recorder = &MockLockModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLockModel) EXPECT() *MockLockModelMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockLockModel) Delete(ctx context.Context, id string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLockModelMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLockModel)(nil).Delete), ctx, id)
+}
+
+// FindOne mocks base method.
+func (m *MockLockModel) FindOne(ctx context.Context, id string) (*model.Lock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOne", ctx, id)
+	ret0, _ := ret[0].(*model.Lock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOne indicates an expected call of FindOne.
+func (mr *MockLockModelMockRecorder) FindOne(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockLockModel)(nil).FindOne), ctx, id)
+}
+
+// FindOneByObjectAndObjectId mocks base method.
+func (m *MockLockModel) FindOneByObjectAndObjectId(ctx context.Context, object, objectId string) (*model.Lock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByObjectAndObjectId", ctx, object, objectId)
+	ret0, _ := ret[0].(*model.Lock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByObjectAndObjectId indicates an expected call of FindOneByObjectAndObjectId.
+func (mr *MockLockModelMockRecorder) FindOneByObjectAndObjectId(ctx, object, objectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByObjectAndObjectId", reflect.TypeOf((*MockLockModel)(nil).FindOneByObjectAndObjectId), ctx, object, objectId)
+}
+
+// Insert mocks base method.
+func (m *MockLockModel) Insert(ctx context.Context, data *model.Lock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockLockModelMockRecorder) Insert(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockLockModel)(nil).Insert), ctx, data)
+}
+
+// Update mocks base method.
+func (m *MockLockModel) Update(ctx context.Context, data *model.Lock) (*mongo.UpdateResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, data)
+	ret0, _ := ret[0].(*mongo.UpdateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockLockModelMockRecorder) Update(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLockModel)(nil).Update), ctx, data)
+}
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: member_model.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	mongo "go.mongodb.org/mongo-driver/mongo"
+)
+
+// MockMemberModel is a mock of MemberModel interface.
+type MockMemberModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockMemberModelMockRecorder
+}
+
+// MockMemberModelMockRecorder is the mock recorder for MockMemberModel.
+type MockMemberModelMockRecorder struct {
+	mock *MockMemberModel
+}
+
+// NewMockMemberModel creates a new mock instance.
+func NewMockMemberModel(ctrl *gomock.Controller) *MockMemberModel {
+	mock := &MockMemberModel{ctrl: ctrl}
+	mock.recorder = &MockMemberModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMemberModel) EXPECT() *MockMemberModelMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockMemberModel) Delete(ctx context.Context, id string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMemberModelMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMemberModel)(nil).Delete), ctx, id)
+}
+
+// DeleteByUserIdAndCommunityId mocks base method.
+func (m *MockMemberModel) DeleteByUserIdAndCommunityId(ctx context.Context, userId, communityId string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserIdAndCommunityId", ctx, userId, communityId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUserIdAndCommunityId indicates an expected call of DeleteByUserIdAndCommunityId.
+func (mr *MockMemberModelMockRecorder) DeleteByUserIdAndCommunityId(ctx, userId, communityId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserIdAndCommunityId", reflect.TypeOf((*MockMemberModel)(nil).DeleteByUserIdAndCommunityId), ctx, userId, communityId)
+}
+
+// FindOne mocks base method.
+func (m *MockMemberModel) FindOne(ctx context.Context, id string) (*model.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOne", ctx, id)
+	ret0, _ := ret[0].(*model.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOne indicates an expected call of FindOne.
+func (mr *MockMemberModelMockRecorder) FindOne(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockMemberModel)(nil).FindOne), ctx, id)
+}
+
+// FindOneByUserIdAndCommunityId mocks base method.
+func (m *MockMemberModel) FindOneByUserIdAndCommunityId(ctx context.Context, userId, communityId string) (*model.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByUserIdAndCommunityId", ctx, userId, communityId)
+	ret0, _ := ret[0].(*model.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByUserIdAndCommunityId indicates an expected call of FindOneByUserIdAndCommunityId.
+func (mr *MockMemberModelMockRecorder) FindOneByUserIdAndCommunityId(ctx, userId, communityId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByUserIdAndCommunityId", reflect.TypeOf((*MockMemberModel)(nil).FindOneByUserIdAndCommunityId), ctx, userId, communityId)
+}
+
+// Insert mocks base method.
+func (m *MockMemberModel) Insert(ctx context.Context, data *model.Member) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockMemberModelMockRecorder) Insert(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockMemberModel)(nil).Insert), ctx, data)
+}
+
+// Update mocks base method.
+func (m *MockMemberModel) Update(ctx context.Context, data *model.Member) (*mongo.UpdateResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, data)
+	ret0, _ := ret[0].(*mongo.UpdateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMemberModelMockRecorder) Update(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMemberModel)(nil).Update), ctx, data)
+}
+
+// UpsertByUserIdAndCommunityId mocks base method.
+func (m *MockMemberModel) UpsertByUserIdAndCommunityId(ctx context.Context, userId, communityId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertByUserIdAndCommunityId", ctx, userId, communityId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertByUserIdAndCommunityId indicates an expected call of UpsertByUserIdAndCommunityId.
+func (mr *MockMemberModelMockRecorder) UpsertByUserIdAndCommunityId(ctx, userId, communityId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertByUserIdAndCommunityId", reflect.TypeOf((*MockMemberModel)(nil).UpsertByUserIdAndCommunityId), ctx, userId, communityId)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: profile_model.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	mongo "go.mongodb.org/mongo-driver/mongo"
+)
+
+// MockProfileModel is a mock of ProfileModel interface.
+type MockProfileModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileModelMockRecorder
+}
+
+// MockProfileModelMockRecorder is the mock recorder for MockProfileModel.
+type MockProfileModelMockRecorder struct {
+	mock *MockProfileModel
+}
+
+// NewMockProfileModel creates a new mock instance.
+func NewMockProfileModel(ctrl *gomock.Controller) *MockProfileModel {
+	mock := &MockProfileModel{ctrl: ctrl}
+	mock.recorder = &MockProfileModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileModel) EXPECT() *MockProfileModelMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockProfileModel) Delete(ctx context.Context, id string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProfileModelMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProfileModel)(nil).Delete), ctx, id)
+}
+
+// FindOne mocks base method.
+func (m *MockProfileModel) FindOne(ctx context.Context, id string) (*model.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOne", ctx, id)
+	ret0, _ := ret[0].(*model.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOne indicates an expected call of FindOne.
+func (mr *MockProfileModelMockRecorder) FindOne(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockProfileModel)(nil).FindOne), ctx, id)
+}
+
+// FindOneByUserId mocks base method.
+func (m *MockProfileModel) FindOneByUserId(ctx context.Context, userId string) (*model.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByUserId", ctx, userId)
+	ret0, _ := ret[0].(*model.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByUserId indicates an expected call of FindOneByUserId.
+func (mr *MockProfileModelMockRecorder) FindOneByUserId(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByUserId", reflect.TypeOf((*MockProfileModel)(nil).FindOneByUserId), ctx, userId)
+}
+
+// Insert mocks base method.
+func (m *MockProfileModel) Insert(ctx context.Context, data *model.Profile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockProfileModelMockRecorder) Insert(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockProfileModel)(nil).Insert), ctx, data)
+}
+
+// Update mocks base method.
+func (m *MockProfileModel) Update(ctx context.Context, data *model.Profile) (*mongo.UpdateResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, data)
+	ret0, _ := ret[0].(*mongo.UpdateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockProfileModelMockRecorder) Update(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProfileModel)(nil).Update), ctx, data)
+}
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: report_model.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	mongo "go.mongodb.org/mongo-driver/mongo"
+)
+
+// MockReportModel is a mock of ReportModel interface.
+type MockReportModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportModelMockRecorder
+}
+
+// MockReportModelMockRecorder is the mock recorder for MockReportModel.
+type MockReportModelMockRecorder struct {
+	mock *MockReportModel
+}
+
+// NewMockReportModel creates a new mock instance.
+func NewMockReportModel(ctrl *gomock.Controller) *MockReportModel {
+	mock := &MockReportModel{ctrl: ctrl}
+	mock.recorder = &MockReportModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportModel) EXPECT() *MockReportModelMockRecorder {
+	return m.recorder
+}
+
+// CloseByObject mocks base method.
+func (m *MockReportModel) CloseByObject(ctx context.Context, object, objectId string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseByObject", ctx, object, objectId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseByObject indicates an expected call of CloseByObject.
+func (mr *MockReportModelMockRecorder) CloseByObject(ctx, object, objectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseByObject", reflect.TypeOf((*MockReportModel)(nil).CloseByObject), ctx, object, objectId)
+}
+
+// Delete mocks base method.
+func (m *MockReportModel) Delete(ctx context.Context, id string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockReportModelMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReportModel)(nil).Delete), ctx, id)
+}
+
+// FindOne mocks base method.
+func (m *MockReportModel) FindOne(ctx context.Context, id string) (*model.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOne", ctx, id)
+	ret0, _ := ret[0].(*model.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOne indicates an expected call of FindOne.
+func (mr *MockReportModelMockRecorder) FindOne(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockReportModel)(nil).FindOne), ctx, id)
+}
+
+// FindOneOpenByUserIdAndObject mocks base method.
+func (m *MockReportModel) FindOneOpenByUserIdAndObject(ctx context.Context, userId, object, objectId string) (*model.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneOpenByUserIdAndObject", ctx, userId, object, objectId)
+	ret0, _ := ret[0].(*model.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneOpenByUserIdAndObject indicates an expected call of FindOneOpenByUserIdAndObject.
+func (mr *MockReportModelMockRecorder) FindOneOpenByUserIdAndObject(ctx, userId, object, objectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneOpenByUserIdAndObject", reflect.TypeOf((*MockReportModel)(nil).FindOneOpenByUserIdAndObject), ctx, userId, object, objectId)
+}
+
+// Insert mocks base method.
+func (m *MockReportModel) Insert(ctx context.Context, data *model.Report) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockReportModelMockRecorder) Insert(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockReportModel)(nil).Insert), ctx, data)
+}
+
+// Update mocks base method.
+func (m *MockReportModel) Update(ctx context.Context, data *model.Report) (*mongo.UpdateResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, data)
+	ret0, _ := ret[0].(*mongo.UpdateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockReportModelMockRecorder) Update(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockReportModel)(nil).Update), ctx, data)
+}
+
+// UpsertOpenByUserIdAndObject mocks base method.
+func (m *MockReportModel) UpsertOpenByUserIdAndObject(ctx context.Context, userId, object, objectId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertOpenByUserIdAndObject", ctx, userId, object, objectId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertOpenByUserIdAndObject indicates an expected call of UpsertOpenByUserIdAndObject.
+func (mr *MockReportModelMockRecorder) UpsertOpenByUserIdAndObject(ctx, userId, object, objectId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertOpenByUserIdAndObject", reflect.TypeOf((*MockReportModel)(nil).UpsertOpenByUserIdAndObject), ctx, userId, object, objectId)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: visibility_model.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	mongo "go.mongodb.org/mongo-driver/mongo"
+)
+
+// MockVisibilityModel is a mock of VisibilityModel interface.
+type MockVisibilityModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockVisibilityModelMockRecorder
+}
+
+// MockVisibilityModelMockRecorder is the mock recorder for MockVisibilityModel.
+type MockVisibilityModelMockRecorder struct {
+	mock *MockVisibilityModel
+}
+
+// NewMockVisibilityModel creates a new mock instance.
+func NewMockVisibilityModel(ctrl *gomock.Controller) *MockVisibilityModel {
+	mock := &MockVisibilityModel{ctrl: ctrl}
+	mock.recorder = &MockVisibilityModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVisibilityModel) EXPECT() *MockVisibilityModelMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockVisibilityModel) Delete(ctx context.Context, id string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockVisibilityModelMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVisibilityModel)(nil).Delete), ctx, id)
+}
+
+// FindOne mocks base method.
+func (m *MockVisibilityModel) FindOne(ctx context.Context, id string) (*model.Visibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOne", ctx, id)
+	ret0, _ := ret[0].(*model.Visibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOne indicates an expected call of FindOne.
+func (mr *MockVisibilityModelMockRecorder) FindOne(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockVisibilityModel)(nil).FindOne), ctx, id)
+}
+
+// FindOneByCommunityId mocks base method.
+func (m *MockVisibilityModel) FindOneByCommunityId(ctx context.Context, communityId string) (*model.Visibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByCommunityId", ctx, communityId)
+	ret0, _ := ret[0].(*model.Visibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByCommunityId indicates an expected call of FindOneByCommunityId.
+func (mr *MockVisibilityModelMockRecorder) FindOneByCommunityId(ctx, communityId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByCommunityId", reflect.TypeOf((*MockVisibilityModel)(nil).FindOneByCommunityId), ctx, communityId)
+}
+
+// Insert mocks base method.
+func (m *MockVisibilityModel) Insert(ctx context.Context, data *model.Visibility) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockVisibilityModelMockRecorder) Insert(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockVisibilityModel)(nil).Insert), ctx, data)
+}
+
+// Update mocks base method.
+func (m *MockVisibilityModel) Update(ctx context.Context, data *model.Visibility) (*mongo.UpdateResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, data)
+	ret0, _ := ret[0].(*mongo.UpdateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockVisibilityModelMockRecorder) Update(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVisibilityModel)(nil).Update), ctx, data)
+}
@@ -3,12 +3,7 @@ package logic
 import (
 	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
 	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
-	cat "github.com/xh-polaris/meowchat-collection-rpc/pb"
-	comment "github.com/xh-polaris/meowchat-comment-rpc/pb"
-	moment "github.com/xh-polaris/meowchat-moment-rpc/pb"
-	post "github.com/xh-polaris/meowchat-post-rpc/pb"
 	. "github.com/xh-polaris/meowchat-system-rpc/constant"
-	system "github.com/xh-polaris/meowchat-system-rpc/pb"
 )
 
 // 社区权限
@@ -28,12 +23,12 @@ func (l *AllowLogic) allowNotice(in *pb.AllowReq) bool {
 		return true
 	}
 
-	notice, _ := l.svcCtx.SystemRPC.RetrieveNotice(l.ctx, &system.RetrieveNoticeReq{Id: in.ObjectId})
-	if notice == nil || notice.Notice == nil {
+	res := l.resolve(ObjectNotice, in.ObjectId)
+	if res == nil {
 		return false
 	}
 
-	return l.allowCommunityOrSuperAdmin(in.UserId, notice.Notice.CommunityId)
+	return l.allowCommunityOrSuperAdmin(in.UserId, res.CommunityId)
 }
 
 // 轮播图权限
@@ -43,27 +38,23 @@ func (l *AllowLogic) allowNews(in *pb.AllowReq) bool {
 		return true
 	}
 
-	news, _ := l.svcCtx.SystemRPC.RetrieveNews(l.ctx, &system.RetrieveNewsReq{Id: in.ObjectId})
-	if news == nil || news.News == nil {
+	res := l.resolve(ObjectNews, in.ObjectId)
+	if res == nil {
 		return false
 	}
 
-	return l.allowCommunityOrSuperAdmin(in.UserId, news.News.CommunityId)
+	return l.allowCommunityOrSuperAdmin(in.UserId, res.CommunityId)
 }
 
 // 帖子权限
-//  允许读，允许超级管理员、帖子发布者写
+//  允许读，允许超级管理员、帖子发布者写，发布者受修改期限和锁定限制
 func (l *AllowLogic) allowPost(in *pb.AllowReq) bool {
 	if in.Action == ActionRead || l.containsRole(in.UserId, RoleSuperAdmin) {
 		return true
 	}
 
-	p, _ := l.svcCtx.PostRPC.RetrievePost(l.ctx, &post.RetrievePostReq{PostId: in.ObjectId})
-	if p == nil || p.Post == nil {
-		return false
-	}
-
-	return p.Post.UserId == in.UserId
+	res := l.resolve(ObjectPost, in.ObjectId)
+	return res != nil && res.OwnerId == in.UserId
 }
 
 // 猫咪信息权限
@@ -73,63 +64,147 @@ func (l *AllowLogic) allowCat(in *pb.AllowReq) bool {
 		return true
 	}
 
-	c, _ := l.svcCtx.CollectionRPC.RetrieveCat(l.ctx, &cat.RetrieveCatReq{CatId: in.ObjectId})
-	if c == nil || c.Cat == nil {
+	res := l.resolve(ObjectCat, in.ObjectId)
+	if res == nil {
 		return false
 	}
 
-	return l.allowCommunityOrSuperAdmin(in.UserId, c.Cat.CommunityId)
+	return l.allowCommunityOrSuperAdmin(in.UserId, res.CommunityId)
 }
 
 // 动态权限
-//  允许读，允许超级管理员、对应社区的管理员、动态发布者写
+//  允许读，允许超级管理员、对应社区的管理员、动态发布者写，发布者受修改期限和锁定限制
 func (l *AllowLogic) allowMoment(in *pb.AllowReq) bool {
 	if in.Action == ActionRead {
 		return true
 	}
 
-	m, _ := l.svcCtx.MomentRPC.RetrieveMoment(l.ctx, &moment.RetrieveMomentReq{MomentId: in.ObjectId})
-	if m == nil || m.Moment == nil {
+	res := l.resolve(ObjectMoment, in.ObjectId)
+	if res == nil {
 		return false
 	}
 
 	// 允许操作自己的moment
-	if m.Moment.UserId == in.UserId {
+	if res.OwnerId == in.UserId {
 		return true
 	}
 
-	return l.allowCommunityOrSuperAdmin(in.UserId, m.Moment.CommunityId)
+	return l.allowCommunityOrSuperAdmin(in.UserId, res.CommunityId)
 }
 
 // 评论权限
-//  允许读，允许超级管理员、评论发布者写
+//  允许读，允许超级管理员、评论发布者写，发布者受修改期限和锁定限制
+//  评论可以属于任意有内置策略的对象，回复按回复链顶端的对象判定
+//  被从属对象发布者拉黑的用户不能新建评论或修改自己的评论
 func (l *AllowLogic) allowComment(in *pb.AllowReq) bool {
-	if in.Action == ActionRead || l.containsRole(in.UserId, RoleSuperAdmin) {
+	if in.Action == ActionRead {
+		return true
+	}
+	if in.ObjectId == "" && in.ParentId != "" {
+		return l.allowCreateComment(in)
+	}
+	if l.containsRole(in.UserId, RoleSuperAdmin) {
 		return true
 	}
 
-	c, _ := l.svcCtx.CommentRPC.RetrieveCommentById(l.ctx, &comment.RetrieveCommentByIdRequest{Id: in.ObjectId})
-	if c == nil || c.Comment == nil {
+	res := l.resolve(ObjectComment, in.ObjectId)
+	if res == nil {
 		return false
 	}
+	object, id := l.commentRoot(res.ParentType, res.ParentId)
 
 	// 允许操作自己的comment
-	if c.Comment.AuthorId == in.UserId {
-		return true
+	if res.OwnerId == in.UserId {
+		return !l.blockedBy(l.resolveOwnerId(object, id), in.UserId)
 	}
 
 	// 如果对评论从属对象有权限，对其下所有评论也有权限
-	allowParentReq := &pb.AllowReq{
+	policy := policies[object]
+	if policy == nil {
+		return false
+	}
+	return l.tracePolicy(object, policy, &pb.AllowReq{
 		UserId:   in.UserId,
-		ObjectId: c.Comment.ParentId,
+		ObjectId: id,
 		Action:   in.Action,
+	})
+}
+
+// 用户资料权限
+//  允许读，允许用户本人和超级管理员写，读受资料可见性限制
+func (l *AllowLogic) allowUser(in *pb.AllowReq) bool {
+	if in.Action == ActionRead {
+		return true
+	}
+	if in.ObjectId == "" {
+		return false
+	}
+
+	return in.UserId == in.ObjectId || l.containsRole(in.UserId, RoleSuperAdmin)
+}
+
+// 社区管理员信息权限
+//  允许读，允许超级管理员、对应社区的管理员写
+//  新建时 objectId 为空，parentObject 为 community，parentId 为所在社区
+//  修改所在社区时 parentId 为新的社区，需要同时有原社区和新社区的权限
+func (l *AllowLogic) allowAdmin(in *pb.AllowReq) bool {
+	if in.Action == ActionRead {
+		return true
+	}
+	if in.ParentId != "" && in.ParentObject != ObjectCommunity {
+		return false
 	}
-	switch c.Comment.Type {
-	case ObjectMoment:
-		return l.allowMoment(allowParentReq)
-	case ObjectPost:
-		return l.allowPost(allowParentReq)
+	if in.ObjectId == "" {
+		return in.ParentId != "" && l.allowCommunityOrSuperAdmin(in.UserId, in.ParentId)
 	}
 
-	return false
+	res := l.resolve(ObjectAdmin, in.ObjectId)
+	if res == nil || !l.allowCommunityOrSuperAdmin(in.UserId, res.CommunityId) {
+		return false
+	}
+	return in.ParentId == "" || in.ParentId == res.CommunityId ||
+		l.allowCommunityOrSuperAdmin(in.UserId, in.ParentId)
+}
+
+// 用户角色权限
+//  objectId 为被修改角色的用户，写时 role 为授予或撤销的角色，修改多个角色时每个角色分别判定
+//  允许用户本人、超级管理员读，允许超级管理员写
+//  社区管理员只能授予或撤销所管理社区及其子社区的社区管理员角色，不能授予或撤销超级管理员
+func (l *AllowLogic) allowRole(in *pb.AllowReq) bool {
+	if in.ObjectId == "" {
+		return false
+	}
+	if in.Action == ActionRead {
+		return in.UserId == in.ObjectId || l.containsRole(in.UserId, RoleSuperAdmin)
+	}
+
+	role := in.GetRole()
+	if role == nil || role.Type == "" {
+		return false
+	}
+	if role.Type != RoleCommunityAdmin || role.CommunityId == "" {
+		return l.containsRole(in.UserId, RoleSuperAdmin)
+	}
+	return l.allowCommunityOrSuperAdmin(in.UserId, role.CommunityId)
+}
+
+// 通用权限，用于只注册了解析器、没有内置策略的对象类型
+//  允许读，允许超级管理员、对象所属社区的管理员、对象发布者写，发布者受修改期限和锁定限制
+func (l *AllowLogic) allowResource(in *pb.AllowReq) bool {
+	if in.Action == ActionRead {
+		return true
+	}
+
+	res := l.resolve(in.Object, in.ObjectId)
+	if res == nil {
+		return false
+	}
+
+	if res.OwnerId != "" && res.OwnerId == in.UserId {
+		return true
+	}
+	if res.CommunityId == "" {
+		return l.containsRole(in.UserId, RoleSuperAdmin)
+	}
+	return l.allowCommunityOrSuperAdmin(in.UserId, res.CommunityId)
 }
@@ -0,0 +1,68 @@
+package logic
+
+import (
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	. "github.com/xh-polaris/meowchat-system-rpc/constant"
+)
+
+// 用户资料的读权限
+//  公开的资料允许所有人读，仅关注者可见的资料允许关注了该用户的用户读，私密的资料只允许本人读
+//  用户本人和超级管理员总是可以读
+func (l *AllowLogic) allowReadProfile(in *pb.AllowReq) bool {
+	if in.Object != ObjectUser {
+		return true
+	}
+	if in.UserId != AnonymousUserId && in.UserId == in.ObjectId {
+		return true
+	}
+
+	visibility := l.profileVisibility(in.ObjectId)
+	switch {
+	case visibility == ProfilePublic:
+		return true
+	case in.UserId == AnonymousUserId:
+		return false
+	case visibility == ProfileFollowers && l.following(in.UserId, in.ObjectId):
+		return true
+	}
+	return l.containsRole(in.UserId, RoleSuperAdmin)
+}
+
+// 查询用户资料的可见性，没有设置时使用配置的默认可见性，查询失败时按私密处理
+func (l *AllowLogic) profileVisibility(userId string) string {
+	ctx, end := l.lookup(upstreamMongo, "FindOneByUserId")
+	p, err := l.svcCtx.ProfileModel.FindOneByUserId(ctx, userId)
+	end(err)
+	switch err {
+	case nil:
+		return p.Visibility
+	case model.ErrNotFound:
+		if v := l.svcCtx.Config.UserProfile.Visibility; v != "" {
+			return v
+		}
+		return ProfilePublic
+	default:
+		l.Errorf("[profileVisibility] find profile failed, err: %v", err)
+		l.degraded = true
+		return ProfilePrivate
+	}
+}
+
+// 判断 userId 是否关注了 followedUserId，查询失败时按未关注处理
+func (l *AllowLogic) following(userId, followedUserId string) bool {
+	ctx, end := l.lookup(upstreamMongo, "FindOneByUserIdAndFollowedUserId")
+	_, err := l.svcCtx.FollowModel.FindOneByUserIdAndFollowedUserId(ctx, userId, followedUserId)
+	end(err)
+	switch err {
+	case nil:
+		return true
+	case model.ErrNotFound:
+		return false
+	default:
+		l.Errorf("[following] find follow failed, err: %v", err)
+		l.degraded = true
+		return false
+	}
+}
@@ -0,0 +1,35 @@
+package logic
+
+import (
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+)
+
+// 使用 OPA 引擎判定
+//  input 包含请求、用户角色和对象属性，修改用户角色时还包含授予或撤销的角色，查询结果不是 true 时一律拒绝
+func (l *AllowLogic) allowRego(p *svc.Policy, in *pb.AllowReq) bool {
+	input := map[string]interface{}{
+		"object":   in.Object,
+		"objectId": in.ObjectId,
+		"action":   in.Action,
+		"user": map[string]interface{}{
+			"id":    in.UserId,
+			"roles": l.resolveRoles(in.UserId),
+		},
+		"resource": l.resolveResource(in.Object, in.ObjectId),
+	}
+	if role := in.GetRole(); role != nil {
+		input["role"] = map[string]interface{}{
+			"type":        role.Type,
+			"communityId": role.CommunityId,
+		}
+	}
+
+	rs, err := p.Rego.Eval(l.ctx, rego.EvalInput(input))
+	if err != nil {
+		l.Errorf("[allowRego] eval failed, err: %v", err)
+		return false
+	}
+	return rs.Allowed()
+}
@@ -0,0 +1,42 @@
+package logic
+
+import (
+	"context"
+
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RemoveMemberLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewRemoveMemberLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RemoveMemberLogic {
+	return &RemoveMemberLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *RemoveMemberLogic) RemoveMember(in *pb.RemoveMemberReq) (*pb.RemoveMemberResp, error) {
+	if in.UserId == "" || in.CommunityId == "" || in.MemberId == "" {
+		return nil, errorx.ErrInvalidArgs
+	}
+	if !NewAllowLogic(l.ctx, l.svcCtx).allowModerate(in.UserId, ObjectCommunity, in.CommunityId) {
+		return nil, errorx.ErrPermissionDenied
+	}
+
+	// 不是成员时不做任何修改
+	_, err := l.svcCtx.MemberModel.DeleteByUserIdAndCommunityId(l.ctx, in.MemberId, in.CommunityId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RemoveMemberResp{}, nil
+}
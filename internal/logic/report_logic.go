@@ -0,0 +1,48 @@
+package logic
+
+import (
+	"context"
+
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ReportLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewReportLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ReportLogic {
+	return &ReportLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *ReportLogic) Report(in *pb.ReportReq) (*pb.ReportResp, error) {
+	if in.UserId == "" || in.Object == "" || in.ObjectId == "" {
+		return nil, errorx.ErrInvalidArgs
+	}
+	if !NewAllowLogic(l.ctx, l.svcCtx).allowReportTarget(&pb.AllowReq{
+		UserId:       in.UserId,
+		Object:       ObjectReport,
+		Action:       ActionWrite,
+		ParentObject: in.Object,
+		ParentId:     in.ObjectId,
+	}) {
+		return nil, errorx.ErrPermissionDenied
+	}
+
+	// 已有未处理的举报时不会插入
+	err := l.svcCtx.ReportModel.UpsertOpenByUserIdAndObject(l.ctx, in.UserId, in.Object, in.ObjectId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ReportResp{}, nil
+}
@@ -0,0 +1,75 @@
+package logic
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/logic/mock"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	pb2 "github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	pb3 "github.com/xh-polaris/meowchat-post-rpc/pb"
+	. "github.com/xh-polaris/meowchat-system-rpc/constant"
+	"github.com/xh-polaris/meowchat-system-rpc/pb"
+)
+
+func TestReportLogic(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
+	mockPostRpc := mock.NewMockPostRpc(ctrl)
+	mockReportModel := mock.NewMockReportModel(ctrl)
+	svcCtx := &svc.ServiceContext{
+		CollectionRPC: mock.NewMockCollectionRpc(ctrl),
+		MomentRPC:     mock.NewMockMomentRpc(ctrl),
+		SystemRPC:     mockSystemRpc,
+		CommentRPC:    mock.NewMockCommentRpc(ctrl),
+		PostRPC:       mockPostRpc,
+		ReportModel:   mockReportModel,
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+	report := NewReportLogic(context.Background(), svcCtx)
+	closeReport := NewCloseReportLogic(context.Background(), svcCtx)
+
+	mockPostRpc.EXPECT().RetrievePost(Any(), Any()).AnyTimes().Return(&pb3.RetrievePostResp{
+		Post: &pb3.Post{
+			Id:     "PostId",
+			UserId: "AuthorId",
+		},
+	}, nil)
+	expectRoles := func(roles ...*pb.Role) {
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{Roles: roles}, nil)
+	}
+
+	Convey("参数不合法时报错", t, func() {
+		_, err := report.Report(&pb2.ReportReq{UserId: "UserId", Object: ObjectPost})
+		So(err, ShouldEqual, errorx.ErrInvalidArgs)
+		_, err = closeReport.CloseReport(&pb2.CloseReportReq{Object: ObjectPost, ObjectId: "PostId"})
+		So(err, ShouldEqual, errorx.ErrInvalidArgs)
+	})
+
+	Convey("举报", t, func() {
+		_, err := report.Report(&pb2.ReportReq{UserId: "AuthorId", Object: ObjectPost, ObjectId: "PostId"})
+		So(err, ShouldEqual, errorx.ErrPermissionDenied)
+
+		// 已有未处理的举报时由存储保证不重复插入
+		mockReportModel.EXPECT().UpsertOpenByUserIdAndObject(Any(), "UserId", ObjectPost, "PostId").Return(nil)
+		_, err = report.Report(&pb2.ReportReq{UserId: "UserId", Object: ObjectPost, ObjectId: "PostId"})
+		So(err, ShouldBeNil)
+	})
+
+	Convey("处理举报", t, func() {
+		expectRoles()
+		_, err := closeReport.CloseReport(&pb2.CloseReportReq{UserId: "UserId", Object: ObjectPost, ObjectId: "PostId"})
+		So(err, ShouldEqual, errorx.ErrPermissionDenied)
+
+		expectRoles(&pb.Role{Type: RoleSuperAdmin})
+		mockReportModel.EXPECT().CloseByObject(Any(), ObjectPost, "PostId").Return(int64(2), nil)
+		_, err = closeReport.CloseReport(&pb2.CloseReportReq{UserId: "SuperAdminId", Object: ObjectPost, ObjectId: "PostId"})
+		So(err, ShouldBeNil)
+	})
+}
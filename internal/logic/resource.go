@@ -0,0 +1,93 @@
+package logic
+
+import (
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/resolver"
+	system "github.com/xh-polaris/meowchat-system-rpc/pb"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// 对象的属性，供外部策略引擎使用
+type resource struct {
+	Id      string `json:"id"`
+	OwnerId string `json:"ownerId,omitempty"`
+	Status  int64  `json:"status,omitempty"`
+	// 对象所属社区
+	Community *community `json:"community,omitempty"`
+}
+
+type community struct {
+	Id       string `json:"id"`
+	ParentId string `json:"parentId,omitempty"`
+}
+
+// 对象所属社区及其父社区，用于匹配社区管理员
+func (r *resource) communityIds() []string {
+	if r == nil || r.Community == nil {
+		return nil
+	}
+	ids := []string{r.Community.Id}
+	if r.Community.ParentId != "" {
+		ids = append(ids, r.Community.ParentId)
+	}
+	return ids
+}
+
+// 查询对象的属性
+//  对象不存在时返回nil
+func (l *AllowLogic) resolveResource(object, id string) *resource {
+	if object == ObjectCommunity {
+		return &resource{
+			Id:        id,
+			Community: l.resolveCommunity(id),
+		}
+	}
+
+	r := l.resolve(object, id)
+	if r == nil {
+		return nil
+	}
+	return &resource{
+		Id:        id,
+		OwnerId:   r.OwnerId,
+		Status:    r.Status,
+		Community: l.resolveCommunity(r.CommunityId),
+	}
+}
+
+// 查询社区及其父社区id
+func (l *AllowLogic) resolveCommunity(id string) *community {
+	if id == "" {
+		return nil
+	}
+	res := &community{Id: id}
+	if c := l.resolve(ObjectCommunity, id); c != nil {
+		res.ParentId = c.CommunityId
+	}
+	return res
+}
+
+// 使用对象类型注册的解析器查询对象的属性，没有解析器或对象不存在时返回nil
+func (l *AllowLogic) resolve(object, id string) *resolver.Resource {
+	r, ok := l.svcCtx.Resolvers.Get(object)
+	if !ok {
+		return nil
+	}
+
+	ctx, end := l.startLookup("resolve/"+object, attribute.String(TraceObject, object))
+	res, err := r.Resolve(ctx, id)
+	end(err)
+	return res
+}
+
+// 查询用户的所有角色
+func (l *AllowLogic) resolveRoles(userId string) []*system.Role {
+	ctx, end := l.lookup(config.UpstreamSystem, "RetrieveUserRole")
+	userRole, err := l.svcCtx.SystemRPC.RetrieveUserRole(ctx, &system.RetrieveUserRoleReq{UserId: userId})
+	end(err)
+	if userRole == nil {
+		return nil
+	}
+	return userRole.Roles
+}
@@ -0,0 +1,48 @@
+package logic
+
+import (
+	"context"
+
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RevokeLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewRevokeLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RevokeLogic {
+	return &RevokeLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *RevokeLogic) Revoke(in *pb.RevokeReq) (*pb.RevokeResp, error) {
+	if in.UserId == "" || in.GranteeId == "" || in.GetRole().GetType() == "" {
+		return nil, errorx.ErrInvalidArgs
+	}
+	if !NewAllowLogic(l.ctx, l.svcCtx).allowRole(&pb.AllowReq{
+		UserId:   in.UserId,
+		Object:   ObjectRole,
+		ObjectId: in.GranteeId,
+		Action:   ActionWrite,
+		Role:     in.Role,
+	}) {
+		return nil, errorx.ErrPermissionDenied
+	}
+
+	// 未授予时不做任何修改
+	_, err := l.svcCtx.GrantModel.DeleteByUserIdAndRole(l.ctx, in.GranteeId, in.Role.Type, in.Role.CommunityId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RevokeResp{}, nil
+}
@@ -0,0 +1,52 @@
+package logic
+
+import (
+	"context"
+
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SetCommunityVisibilityLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewSetCommunityVisibilityLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SetCommunityVisibilityLogic {
+	return &SetCommunityVisibilityLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *SetCommunityVisibilityLogic) SetCommunityVisibility(in *pb.SetCommunityVisibilityReq) (*pb.SetCommunityVisibilityResp, error) {
+	if in.UserId == "" || in.CommunityId == "" {
+		return nil, errorx.ErrInvalidArgs
+	}
+	if !NewAllowLogic(l.ctx, l.svcCtx).allowModerate(in.UserId, ObjectCommunity, in.CommunityId) {
+		return nil, errorx.ErrPermissionDenied
+	}
+
+	visibility, err := l.svcCtx.VisibilityModel.FindOneByCommunityId(l.ctx, in.CommunityId)
+	switch err {
+	case nil:
+		visibility.Private = in.Private
+		_, err = l.svcCtx.VisibilityModel.Update(l.ctx, visibility)
+	case model.ErrNotFound:
+		err = l.svcCtx.VisibilityModel.Insert(l.ctx, &model.Visibility{
+			CommunityId: in.CommunityId,
+			Private:     in.Private,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SetCommunityVisibilityResp{}, nil
+}
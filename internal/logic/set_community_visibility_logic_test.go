@@ -0,0 +1,73 @@
+package logic
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/logic/mock"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	pb2 "github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	. "github.com/xh-polaris/meowchat-system-rpc/constant"
+	"github.com/xh-polaris/meowchat-system-rpc/pb"
+)
+
+func TestSetCommunityVisibilityLogic(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
+	mockVisibilityModel := mock.NewMockVisibilityModel(ctrl)
+	svcCtx := &svc.ServiceContext{
+		SystemRPC:       mockSystemRpc,
+		VisibilityModel: mockVisibilityModel,
+	}
+	svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+	l := NewSetCommunityVisibilityLogic(context.Background(), svcCtx)
+
+	expectRoles := func(roles ...*pb.Role) {
+		mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).Return(&pb.RetrieveUserRoleResp{Roles: roles}, nil)
+	}
+	communityAdmin := &pb.Role{Type: RoleCommunityAdmin, CommunityId: "CommunityId"}
+
+	Convey("参数不合法时报错", t, func() {
+		_, err := l.SetCommunityVisibility(&pb2.SetCommunityVisibilityReq{CommunityId: "CommunityId"})
+		So(err, ShouldEqual, errorx.ErrInvalidArgs)
+	})
+
+	Convey("普通用户不能设置社区可见性", t, func() {
+		expectRoles()
+		_, err := l.SetCommunityVisibility(&pb2.SetCommunityVisibilityReq{
+			UserId:      "UserId",
+			CommunityId: "CommunityId",
+			Private:     true,
+		})
+		So(err, ShouldEqual, errorx.ErrPermissionDenied)
+	})
+
+	Convey("社区管理员把社区设为私有", t, func() {
+		expectRoles(communityAdmin)
+		mockVisibilityModel.EXPECT().FindOneByCommunityId(Any(), "CommunityId").Return(nil, model.ErrNotFound)
+		mockVisibilityModel.EXPECT().Insert(Any(), &model.Visibility{CommunityId: "CommunityId", Private: true}).Return(nil)
+		_, err := l.SetCommunityVisibility(&pb2.SetCommunityVisibilityReq{
+			UserId:      "AdminId",
+			CommunityId: "CommunityId",
+			Private:     true,
+		})
+		So(err, ShouldBeNil)
+	})
+
+	Convey("社区管理员把私有社区设为公开", t, func() {
+		expectRoles(communityAdmin)
+		mockVisibilityModel.EXPECT().FindOneByCommunityId(Any(), "CommunityId").Return(&model.Visibility{CommunityId: "CommunityId", Private: true}, nil)
+		mockVisibilityModel.EXPECT().Update(Any(), &model.Visibility{CommunityId: "CommunityId"}).Return(nil, nil)
+		_, err := l.SetCommunityVisibility(&pb2.SetCommunityVisibilityReq{
+			UserId:      "AdminId",
+			CommunityId: "CommunityId",
+		})
+		So(err, ShouldBeNil)
+	})
+}
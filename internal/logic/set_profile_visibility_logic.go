@@ -0,0 +1,52 @@
+package logic
+
+import (
+	"context"
+
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SetProfileVisibilityLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewSetProfileVisibilityLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SetProfileVisibilityLogic {
+	return &SetProfileVisibilityLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *SetProfileVisibilityLogic) SetProfileVisibility(in *pb.SetProfileVisibilityReq) (*pb.SetProfileVisibilityResp, error) {
+	switch {
+	case in.UserId == "":
+		return nil, errorx.ErrInvalidArgs
+	case in.Visibility != ProfilePublic && in.Visibility != ProfileFollowers && in.Visibility != ProfilePrivate:
+		return nil, errorx.ErrInvalidArgs
+	}
+
+	profile, err := l.svcCtx.ProfileModel.FindOneByUserId(l.ctx, in.UserId)
+	switch err {
+	case nil:
+		profile.Visibility = in.Visibility
+		_, err = l.svcCtx.ProfileModel.Update(l.ctx, profile)
+	case model.ErrNotFound:
+		err = l.svcCtx.ProfileModel.Insert(l.ctx, &model.Profile{
+			UserId:     in.UserId,
+			Visibility: in.Visibility,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SetProfileVisibilityResp{}, nil
+}
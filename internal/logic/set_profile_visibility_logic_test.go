@@ -0,0 +1,44 @@
+package logic
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/logic/mock"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	pb2 "github.com/xh-polaris/meowchat-authorization-rpc/pb"
+)
+
+func TestSetProfileVisibilityLogic(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	mockProfileModel := mock.NewMockProfileModel(ctrl)
+	l := NewSetProfileVisibilityLogic(context.Background(), &svc.ServiceContext{ProfileModel: mockProfileModel})
+
+	Convey("参数不合法时报错", t, func() {
+		_, err := l.SetProfileVisibility(&pb2.SetProfileVisibilityReq{Visibility: ProfilePublic})
+		So(err, ShouldEqual, errorx.ErrInvalidArgs)
+		_, err = l.SetProfileVisibility(&pb2.SetProfileVisibilityReq{UserId: "UserId", Visibility: "friends"})
+		So(err, ShouldEqual, errorx.ErrInvalidArgs)
+	})
+
+	Convey("没有资料时新建", t, func() {
+		mockProfileModel.EXPECT().FindOneByUserId(Any(), "UserId").Return(nil, model.ErrNotFound)
+		mockProfileModel.EXPECT().Insert(Any(), &model.Profile{UserId: "UserId", Visibility: ProfilePrivate}).Return(nil)
+		_, err := l.SetProfileVisibility(&pb2.SetProfileVisibilityReq{UserId: "UserId", Visibility: ProfilePrivate})
+		So(err, ShouldBeNil)
+	})
+
+	Convey("已有资料时更新", t, func() {
+		mockProfileModel.EXPECT().FindOneByUserId(Any(), "UserId").Return(&model.Profile{UserId: "UserId", Visibility: ProfilePublic}, nil)
+		mockProfileModel.EXPECT().Update(Any(), &model.Profile{UserId: "UserId", Visibility: ProfileFollowers}).Return(nil, nil)
+		_, err := l.SetProfileVisibility(&pb2.SetProfileVisibilityReq{UserId: "UserId", Visibility: ProfileFollowers})
+		So(err, ShouldBeNil)
+	})
+}
@@ -0,0 +1,72 @@
+package logic
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	"github.com/zeromicro/go-zero/core/contextx"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/metric"
+)
+
+var (
+	shadowEvaluations = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: "authorization",
+		Subsystem: "shadow",
+		Name:      "evaluations_total",
+		Help:      "authorization shadow policy evaluations.",
+		Labels:    []string{"object", "action"},
+	})
+	shadowDisagreements = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: "authorization",
+		Subsystem: "shadow",
+		Name:      "disagreements_total",
+		Help:      "authorization shadow policy decisions that differ from the active policy.",
+		Labels:    []string{"object", "action", "active", "shadow"},
+	})
+	shadowDropped = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: "authorization",
+		Subsystem: "shadow",
+		Name:      "dropped_total",
+		Help:      "authorization shadow policy evaluations dropped because the queue is full.",
+		Labels:    []string{"object", "action"},
+	})
+)
+
+// 在影子策略集上异步重新判定，只记录与生效策略不一致的结果，不影响返回值
+//  生效策略降级时不会调用，未抽中或队列已满时不判定
+func (l *AllowLogic) evaluateShadow(in *pb.AllowReq, allow bool) {
+	s := l.svcCtx.Shadow
+	if s == nil || !s.Sample() {
+		return
+	}
+
+	// 请求结束后继续判定，只保留上下文中的值
+	valueCtx := contextx.ValueOnlyFrom(l.ctx)
+	submitted := s.Submit(func() {
+		ctx, cancel := context.WithTimeout(valueCtx, s.Timeout)
+		defer cancel()
+
+		sl := NewAllowLogic(ctx, l.svcCtx)
+		shadow := sl.evaluate(s.Policy, in)
+		shadowEvaluations.Inc(in.Object, in.Action)
+		// 影子判定降级时结果不可信，不记录
+		if sl.degraded || shadow == allow {
+			return
+		}
+
+		shadowDisagreements.Inc(in.Object, in.Action, strconv.FormatBool(allow), strconv.FormatBool(shadow))
+		logx.WithContext(ctx).Infow("shadow policy disagreement",
+			logx.Field("userId", in.UserId),
+			logx.Field("object", in.Object),
+			logx.Field("objectId", in.ObjectId),
+			logx.Field("action", in.Action),
+			logx.Field("active", allow),
+			logx.Field("shadow", shadow),
+		)
+	})
+	if !submitted {
+		shadowDropped.Inc(in.Object, in.Action)
+	}
+}
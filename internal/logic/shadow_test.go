@@ -0,0 +1,145 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/golang/mock/gomock"
+	prom "github.com/prometheus/client_golang/prometheus"
+	. "github.com/smartystreets/goconvey/convey"
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/logic/mock"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	pb2 "github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	pb3 "github.com/xh-polaris/meowchat-post-rpc/pb"
+	"github.com/xh-polaris/meowchat-system-rpc/pb"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/prometheus"
+)
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// 返回 authorization_shadow 下指标的当前值
+func shadowCounter(name string) float64 {
+	families, err := prom.DefaultGatherer.Gather()
+	So(err, ShouldBeNil)
+	var total float64
+	for _, f := range families {
+		if f.GetName() != "authorization_shadow_"+name {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			total += m.GetCounter().GetValue()
+		}
+	}
+	return total
+}
+
+func TestAllowLogic_Allow_Shadow(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	// 影子策略集允许所有请求，与内置策略不一致
+	regoPath := filepath.Join(t.TempDir(), "shadow.rego")
+	err := os.WriteFile(regoPath, []byte("package authorization\n\ndefault allow = true\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shadowPolicy := svc.MustNewPolicy(config.PolicyConf{
+		Engine: config.EngineRego,
+		Rego: config.RegoConf{
+			Path:  regoPath,
+			Query: "data.authorization.allow",
+		},
+	})
+
+	prometheus.StartAgent(prometheus.Config{Host: "127.0.0.1", Port: 0, Path: "/metrics"})
+	logs := &syncBuffer{}
+	logx.SetWriter(logx.NewWriter(logs))
+	defer logx.Reset()
+
+	mockSystemRpc := mock.NewMockSystemRpc(ctrl)
+	mockPostRpc := mock.NewMockPostRpc(ctrl)
+	mockPostRpc.EXPECT().RetrievePost(Any(), Any()).AnyTimes().Return(&pb3.RetrievePostResp{
+		Post: &pb3.Post{
+			Id:     "PostId",
+			UserId: "AnotherUserId",
+		},
+	}, nil)
+	mockSystemRpc.EXPECT().RetrieveUserRole(Any(), Any()).AnyTimes().Return(&pb.RetrieveUserRoleResp{}, nil)
+
+	newLogic := func(c config.ShadowConf) (*AllowLogic, *svc.Shadow) {
+		svcCtx := &svc.ServiceContext{
+			Config:        config.Config{},
+			CollectionRPC: mock.NewMockCollectionRpc(ctrl),
+			MomentRPC:     mock.NewMockMomentRpc(ctrl),
+			SystemRPC:     mockSystemRpc,
+			CommentRPC:    mock.NewMockCommentRpc(ctrl),
+			PostRPC:       mockPostRpc,
+			Shadow:        svc.NewShadow(c, shadowPolicy),
+		}
+		svcCtx.Resolvers = svc.NewResolvers(svcCtx)
+		return NewAllowLogic(context.Background(), svcCtx), svcCtx.Shadow
+	}
+	req := &pb2.AllowReq{
+		UserId:   "UserId",
+		Object:   ObjectPost,
+		ObjectId: "PostId",
+		Action:   ActionWrite,
+	}
+
+	Convey("影子策略集不影响返回值，并记录不一致的结果", t, func() {
+		l, _ := newLogic(config.ShadowConf{Workers: 1, QueueSize: 1})
+		disagreements := shadowCounter("disagreements_total")
+
+		allow, err := l.Allow(req)
+		So(err, ShouldBeNil)
+		So(allow.Allow, ShouldBeFalse)
+
+		deadline := time.Now().Add(time.Second)
+		for !strings.Contains(logs.String(), "shadow policy disagreement") && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		So(logs.String(), ShouldContainSubstring, "shadow policy disagreement")
+		So(shadowCounter("disagreements_total"), ShouldEqual, disagreements+1)
+	})
+
+	Convey("队列已满时丢弃影子判定", t, func() {
+		l, s := newLogic(config.ShadowConf{Workers: 1, QueueSize: 0})
+		dropped := shadowCounter("dropped_total")
+
+		// 占住唯一的协程
+		block := make(chan struct{})
+		defer close(block)
+		for !s.Submit(func() { <-block }) {
+			time.Sleep(time.Millisecond)
+		}
+
+		allow, err := l.Allow(req)
+		So(err, ShouldBeNil)
+		So(allow.Allow, ShouldBeFalse)
+		So(shadowCounter("dropped_total"), ShouldEqual, dropped+1)
+	})
+}
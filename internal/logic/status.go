@@ -0,0 +1,40 @@
+package logic
+
+import (
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	. "github.com/xh-polaris/meowchat-system-rpc/constant"
+)
+
+// 隐藏和草稿内容的读权限
+//  隐藏状态只允许发布者和超级管理员读，草稿状态只允许发布者读
+//  目前只有帖子有状态，动态等其他对象不受限制
+func (l *AllowLogic) allowReadStatus(in *pb.AllowReq) bool {
+	c := l.svcCtx.Config.ContentStatus
+	if in.Object != ObjectPost || len(c.Hidden) == 0 && len(c.Draft) == 0 {
+		return true
+	}
+
+	res := l.resolve(ObjectPost, in.ObjectId)
+	if res == nil {
+		return true
+	}
+
+	owner := in.UserId != AnonymousUserId && res.OwnerId == in.UserId
+	switch {
+	case containsStatus(c.Draft, res.Status):
+		return owner
+	case containsStatus(c.Hidden, res.Status):
+		return owner || in.UserId != AnonymousUserId && l.containsRole(in.UserId, RoleSuperAdmin)
+	}
+	return true
+}
+
+func containsStatus(statuses []int64, status int64) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package logic
+
+import (
+	"context"
+
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	ztrace "github.com/zeromicro/go-zero/core/trace"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 存储的名字，用于查询 span
+const upstreamMongo = "mongo"
+
+// 每次使用时获取，以便使用 go-zero 启动后设置的 TracerProvider
+func tracer() trace.Tracer {
+	return otel.Tracer(ztrace.TraceName)
+}
+
+// 开始一个 span，之后的上游查询都在这个 span 下，返回的函数结束 span 并恢复上下文
+func (l *AllowLogic) startSpan(name string, attrs ...attribute.KeyValue) (trace.Span, func()) {
+	parent := l.ctx
+	ctx, span := tracer().Start(parent, name, trace.WithAttributes(attrs...))
+	l.ctx = ctx
+	return span, func() {
+		span.End()
+		l.ctx = parent
+	}
+}
+
+// 在 span 中执行一个内置策略函数
+//  评论判定从属对象时也经过这里，一次判定中调用的每个策略函数都有自己的 span
+func (l *AllowLogic) tracePolicy(object string, policy func(*AllowLogic, *pb.AllowReq) bool,
+	in *pb.AllowReq) bool {
+	span, end := l.startSpan("policy/"+object,
+		attribute.String(TraceObject, object),
+		attribute.String(TraceAction, in.Action),
+	)
+	defer end()
+
+	allow := policy(l, in)
+	span.SetAttributes(attribute.Bool(TraceDecision, allow))
+	return allow
+}
+
+// 开始一次上游查询，使用返回的上下文调用上游服务，返回的函数结束查询
+//  结束时记录上游服务的错误，服务不可用时判定降级，对象不存在不算错误
+func (l *AllowLogic) lookup(upstream, method string) (context.Context, func(error)) {
+	return l.startLookup("lookup/"+upstream+"/"+method, attribute.String(TraceUpstream, upstream))
+}
+
+func (l *AllowLogic) startLookup(name string, attrs ...attribute.KeyValue) (context.Context, func(error)) {
+	ctx, span := tracer().Start(l.ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	return ctx, func(err error) {
+		defer span.End()
+		if err == nil || err == model.ErrNotFound {
+			return
+		}
+		span.RecordError(err)
+		// 上游服务返回的业务错误如对象不存在不标记为失败，存储的错误没有状态码
+		if l.observe(err) || status.Code(err) == grpccodes.Unknown {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UnblockLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewUnblockLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UnblockLogic {
+	return &UnblockLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *UnblockLogic) Unblock(in *pb.UnblockReq) (*pb.UnblockResp, error) {
+	if in.UserId == "" || in.BlockedUserId == "" {
+		return nil, errorx.ErrInvalidArgs
+	}
+
+	// 未拉黑时不做任何修改
+	_, err := l.svcCtx.BlockModel.DeleteByUserIdAndBlockedUserId(l.ctx, in.UserId, in.BlockedUserId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UnblockResp{}, nil
+}
@@ -0,0 +1,38 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UnfollowLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewUnfollowLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UnfollowLogic {
+	return &UnfollowLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *UnfollowLogic) Unfollow(in *pb.UnfollowReq) (*pb.UnfollowResp, error) {
+	if in.UserId == "" || in.FollowedUserId == "" {
+		return nil, errorx.ErrInvalidArgs
+	}
+
+	// 未关注时不做任何修改
+	_, err := l.svcCtx.FollowModel.DeleteByUserIdAndFollowedUserId(l.ctx, in.UserId, in.FollowedUserId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UnfollowResp{}, nil
+}
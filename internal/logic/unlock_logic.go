@@ -0,0 +1,50 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/xh-polaris/meowchat-authorization-rpc/errorx"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/svc"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UnlockLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewUnlockLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UnlockLogic {
+	return &UnlockLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *UnlockLogic) Unlock(in *pb.UnlockReq) (*pb.UnlockResp, error) {
+	if in.UserId == "" || in.Object == "" || in.ObjectId == "" {
+		return nil, errorx.ErrInvalidArgs
+	}
+	if !NewAllowLogic(l.ctx, l.svcCtx).allowModerate(in.UserId, in.Object, in.ObjectId) {
+		return nil, errorx.ErrPermissionDenied
+	}
+
+	// 未锁定时直接返回
+	lock, err := l.svcCtx.LockModel.FindOneByObjectAndObjectId(l.ctx, in.Object, in.ObjectId)
+	switch err {
+	case nil:
+	case model.ErrNotFound:
+		return &pb.UnlockResp{}, nil
+	default:
+		return nil, err
+	}
+
+	if _, err = l.svcCtx.LockModel.Delete(l.ctx, lock.ID.Hex()); err != nil {
+		return nil, err
+	}
+	return &pb.UnlockResp{}, nil
+}
@@ -0,0 +1,87 @@
+package logic
+
+import (
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+)
+
+// 私有社区的读权限
+//  私有社区中的对象只允许社区成员、超级管理员和社区管理员读，未启用私有社区或对象不属于社区时不限制
+func (l *AllowLogic) allowReadCommunity(in *pb.AllowReq) bool {
+	if !l.svcCtx.Config.PrivateCommunity {
+		return true
+	}
+
+	communityId := l.resolveCommunityId(in.Object, in.ObjectId)
+	if communityId == "" || !l.privateCommunity(communityId) {
+		return true
+	}
+	if in.UserId == AnonymousUserId {
+		return false
+	}
+
+	ctx, end := l.lookup(upstreamMongo, "FindOneByUserIdAndCommunityId")
+	_, err := l.svcCtx.MemberModel.FindOneByUserIdAndCommunityId(ctx, in.UserId, communityId)
+	end(err)
+	switch err {
+	case nil:
+		return true
+	case model.ErrNotFound:
+	default:
+		l.Errorf("[allowReadCommunity] find member failed, err: %v", err)
+		l.degraded = true
+	}
+
+	return l.allowCommunityOrSuperAdmin(in.UserId, communityId)
+}
+
+// 判断用户能否读被评论、点赞或举报的对象，受私有社区和内容状态的读限制
+func (l *AllowLogic) allowReadTarget(userId, object, id string) bool {
+	target := &pb.AllowReq{
+		UserId:   userId,
+		Object:   object,
+		ObjectId: id,
+		Action:   ActionRead,
+	}
+	return l.allowReadCommunity(target) && l.allowReadStatus(target)
+}
+
+// 判断社区是否私有，查询失败时按私有处理
+func (l *AllowLogic) privateCommunity(communityId string) bool {
+	ctx, end := l.lookup(upstreamMongo, "FindOneByCommunityId")
+	v, err := l.svcCtx.VisibilityModel.FindOneByCommunityId(ctx, communityId)
+	end(err)
+	switch err {
+	case nil:
+		return v.Private
+	case model.ErrNotFound:
+		return false
+	default:
+		l.Errorf("[privateCommunity] find visibility failed, err: %v", err)
+		l.degraded = true
+		return true
+	}
+}
+
+// 查询对象所属的社区，没有所属社区的对象属于其从属对象的社区，如评论
+//  对象不存在或不属于社区时返回空
+func (l *AllowLogic) resolveCommunityId(object, id string) string {
+	if object == ObjectCommunity {
+		return id
+	}
+
+	res := l.resolve(object, id)
+	switch {
+	case res == nil:
+		return ""
+	case res.CommunityId != "":
+		return res.CommunityId
+	// 回复属于回复链顶端对象的社区
+	case res.ParentType != "":
+		if object, id := l.commentRoot(res.ParentType, res.ParentId); object != "" {
+			return l.resolveCommunityId(object, id)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const BlockCollectionName = "block"
+
+var _ BlockModel = (*CustomBlockModel)(nil)
+
+type (
+	// BlockModel is an interface to be customized, add more methods here,
+	// and implement the added methods in CustomBlockModel.
+	BlockModel interface {
+		blockModel
+		FindOneByUserIdAndBlockedUserId(ctx context.Context, userId, blockedUserId string) (*Block, error)
+		FindManyByUserId(ctx context.Context, userId string, skip, count int64) ([]*Block, int64, error)
+		UpsertByUserIdAndBlockedUserId(ctx context.Context, userId, blockedUserId string) error
+		DeleteByUserIdAndBlockedUserId(ctx context.Context, userId, blockedUserId string) (int64, error)
+	}
+
+	CustomBlockModel struct {
+		*defaultBlockModel
+	}
+)
+
+func (m CustomBlockModel) FindOneByUserIdAndBlockedUserId(ctx context.Context, userId, blockedUserId string) (*Block, error) {
+	var data Block
+	err := m.conn.FindOneNoCache(ctx, &data, bson.M{"userId": userId, "blockedUserId": blockedUserId})
+	switch err {
+	case nil:
+		return &data, nil
+	case monc.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
+
+func (m CustomBlockModel) FindManyByUserId(ctx context.Context, userId string, skip, count int64) ([]*Block, int64, error) {
+	var resp []*Block
+	filter := bson.M{"userId": userId}
+	opts := options.Find().SetSort(bson.M{"createAt": -1}).SetSkip(skip).SetLimit(count)
+	if err := m.conn.Find(ctx, &resp, filter, opts); err != nil {
+		return nil, 0, err
+	}
+	total, err := m.conn.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp, total, nil
+}
+
+// UpsertByUserIdAndBlockedUserId 在拉黑记录不存在时插入，并发拉黑时不会重复插入
+func (m CustomBlockModel) UpsertByUserIdAndBlockedUserId(ctx context.Context, userId, blockedUserId string) error {
+	now := time.Now()
+	// 插入时 filter 中的等值条件会写入文档
+	update := bson.M{"$setOnInsert": bson.M{"createAt": now, "updateAt": now}}
+	filter := bson.M{"userId": userId, "blockedUserId": blockedUserId}
+	_, err := m.conn.UpdateOneNoCache(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// DeleteByUserIdAndBlockedUserId 删除所有相同的拉黑记录
+func (m CustomBlockModel) DeleteByUserIdAndBlockedUserId(ctx context.Context, userId, blockedUserId string) (int64, error) {
+	return m.conn.DeleteMany(ctx, bson.M{"userId": userId, "blockedUserId": blockedUserId})
+}
+
+// NewBlockModel returns a model for the mongo.
+func NewBlockModel(url, db, collection string, c cache.CacheConf) BlockModel {
+	conn := monc.MustNewModel(url, db, collection, c)
+	return &CustomBlockModel{
+		defaultBlockModel: newDefaultBlockModel(conn),
+	}
+}
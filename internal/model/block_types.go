@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Block 表示 UserId 拉黑了 BlockedUserId
+type Block struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserId        string             `bson:"userId,omitempty" json:"userId,omitempty"`
+	BlockedUserId string             `bson:"blockedUserId,omitempty" json:"blockedUserId,omitempty"`
+	UpdateAt      time.Time          `bson:"updateAt,omitempty" json:"updateAt,omitempty"`
+	CreateAt      time.Time          `bson:"createAt,omitempty" json:"createAt,omitempty"`
+}
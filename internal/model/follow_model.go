@@ -0,0 +1,66 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const FollowCollectionName = "follow"
+
+var _ FollowModel = (*CustomFollowModel)(nil)
+
+type (
+	// FollowModel is an interface to be customized, add more methods here,
+	// and implement the added methods in CustomFollowModel.
+	FollowModel interface {
+		followModel
+		FindOneByUserIdAndFollowedUserId(ctx context.Context, userId, followedUserId string) (*Follow, error)
+		UpsertByUserIdAndFollowedUserId(ctx context.Context, userId, followedUserId string) error
+		DeleteByUserIdAndFollowedUserId(ctx context.Context, userId, followedUserId string) (int64, error)
+	}
+
+	CustomFollowModel struct {
+		*defaultFollowModel
+	}
+)
+
+func (m CustomFollowModel) FindOneByUserIdAndFollowedUserId(ctx context.Context, userId, followedUserId string) (*Follow, error) {
+	var data Follow
+	err := m.conn.FindOneNoCache(ctx, &data, bson.M{"userId": userId, "followedUserId": followedUserId})
+	switch err {
+	case nil:
+		return &data, nil
+	case monc.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
+
+// UpsertByUserIdAndFollowedUserId 在关注记录不存在时插入，并发关注时不会重复插入
+func (m CustomFollowModel) UpsertByUserIdAndFollowedUserId(ctx context.Context, userId, followedUserId string) error {
+	now := time.Now()
+	// 插入时 filter 中的等值条件会写入文档
+	update := bson.M{"$setOnInsert": bson.M{"createAt": now, "updateAt": now}}
+	filter := bson.M{"userId": userId, "followedUserId": followedUserId}
+	_, err := m.conn.UpdateOneNoCache(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// DeleteByUserIdAndFollowedUserId 删除所有相同的关注记录
+func (m CustomFollowModel) DeleteByUserIdAndFollowedUserId(ctx context.Context, userId, followedUserId string) (int64, error) {
+	return m.conn.DeleteMany(ctx, bson.M{"userId": userId, "followedUserId": followedUserId})
+}
+
+// NewFollowModel returns a model for the mongo.
+func NewFollowModel(url, db, collection string, c cache.CacheConf) FollowModel {
+	conn := monc.MustNewModel(url, db, collection, c)
+	return &CustomFollowModel{
+		defaultFollowModel: newDefaultFollowModel(conn),
+	}
+}
@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Follow 表示 UserId 关注了 FollowedUserId，由 follow 和 unfollow 接口维护
+type Follow struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserId         string             `bson:"userId,omitempty" json:"userId,omitempty"`
+	FollowedUserId string             `bson:"followedUserId,omitempty" json:"followedUserId,omitempty"`
+	UpdateAt       time.Time          `bson:"updateAt,omitempty" json:"updateAt,omitempty"`
+	CreateAt       time.Time          `bson:"createAt,omitempty" json:"createAt,omitempty"`
+}
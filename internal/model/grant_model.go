@@ -0,0 +1,70 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const GrantCollectionName = "grant"
+
+var _ GrantModel = (*CustomGrantModel)(nil)
+
+type (
+	// GrantModel is an interface to be customized, add more methods here,
+	// and implement the added methods in CustomGrantModel.
+	GrantModel interface {
+		grantModel
+		ListByUserId(ctx context.Context, userId string) ([]*Grant, error)
+		UpsertByUserIdAndRole(ctx context.Context, userId, role, communityId string) error
+		DeleteByUserIdAndRole(ctx context.Context, userId, role, communityId string) (int64, error)
+	}
+
+	CustomGrantModel struct {
+		*defaultGrantModel
+	}
+)
+
+func (m CustomGrantModel) ListByUserId(ctx context.Context, userId string) ([]*Grant, error) {
+	var resp []*Grant
+	err := m.conn.Find(ctx, &resp, bson.M{"userId": userId})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpsertByUserIdAndRole 在授予不存在时插入，并发授予时不会重复插入
+func (m CustomGrantModel) UpsertByUserIdAndRole(ctx context.Context, userId, role, communityId string) error {
+	now := time.Now()
+	// 插入时 filter 中的等值条件会写入文档
+	update := bson.M{"$setOnInsert": bson.M{"createAt": now, "updateAt": now}}
+	_, err := m.conn.UpdateOneNoCache(ctx, grantFilter(userId, role, communityId), update, options.Update().SetUpsert(true))
+	return err
+}
+
+// DeleteByUserIdAndRole 删除所有相同的授予
+func (m CustomGrantModel) DeleteByUserIdAndRole(ctx context.Context, userId, role, communityId string) (int64, error) {
+	return m.conn.DeleteMany(ctx, grantFilter(userId, role, communityId))
+}
+
+func grantFilter(userId, role, communityId string) bson.M {
+	filter := bson.M{"userId": userId, "role": role, "communityId": communityId}
+	// communityId 为空时不会写入文档
+	if communityId == "" {
+		filter["communityId"] = bson.M{"$exists": false}
+	}
+	return filter
+}
+
+// NewGrantModel returns a model for the mongo.
+func NewGrantModel(url, db, collection string, c cache.CacheConf) GrantModel {
+	conn := monc.MustNewModel(url, db, collection, c)
+	return &CustomGrantModel{
+		defaultGrantModel: newDefaultGrantModel(conn),
+	}
+}
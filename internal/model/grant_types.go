@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Grant 是本服务自行维护的角色授予，由 grant 和 revoke 接口写入，与 system-rpc 中的用户角色一起参与 casbin 判定
+type Grant struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserId      string             `bson:"userId,omitempty" json:"userId,omitempty"`
+	Role        string             `bson:"role,omitempty" json:"role,omitempty"`
+	CommunityId string             `bson:"communityId,omitempty" json:"communityId,omitempty"`
+	UpdateAt    time.Time          `bson:"updateAt,omitempty" json:"updateAt,omitempty"`
+	CreateAt    time.Time          `bson:"createAt,omitempty" json:"createAt,omitempty"`
+}
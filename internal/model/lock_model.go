@@ -0,0 +1,47 @@
+package model
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const LockCollectionName = "lock"
+
+var _ LockModel = (*CustomLockModel)(nil)
+
+type (
+	// LockModel is an interface to be customized, add more methods here,
+	// and implement the added methods in CustomLockModel.
+	LockModel interface {
+		lockModel
+		FindOneByObjectAndObjectId(ctx context.Context, object, objectId string) (*Lock, error)
+	}
+
+	CustomLockModel struct {
+		*defaultLockModel
+	}
+)
+
+func (m CustomLockModel) FindOneByObjectAndObjectId(ctx context.Context, object, objectId string) (*Lock, error) {
+	var data Lock
+	err := m.conn.FindOneNoCache(ctx, &data, bson.M{"object": object, "objectId": objectId})
+	switch err {
+	case nil:
+		return &data, nil
+	case monc.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
+
+// NewLockModel returns a model for the mongo.
+func NewLockModel(url, db, collection string, c cache.CacheConf) LockModel {
+	conn := monc.MustNewModel(url, db, collection, c)
+	return &CustomLockModel{
+		defaultLockModel: newDefaultLockModel(conn),
+	}
+}
@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Lock 表示对象已被锁定，发布者不能再修改
+type Lock struct {
+	ID       primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Object   string             `bson:"object,omitempty" json:"object,omitempty"`
+	ObjectId string             `bson:"objectId,omitempty" json:"objectId,omitempty"`
+	UpdateAt time.Time          `bson:"updateAt,omitempty" json:"updateAt,omitempty"`
+	CreateAt time.Time          `bson:"createAt,omitempty" json:"createAt,omitempty"`
+}
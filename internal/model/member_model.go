@@ -0,0 +1,66 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const MemberCollectionName = "member"
+
+var _ MemberModel = (*CustomMemberModel)(nil)
+
+type (
+	// MemberModel is an interface to be customized, add more methods here,
+	// and implement the added methods in CustomMemberModel.
+	MemberModel interface {
+		memberModel
+		FindOneByUserIdAndCommunityId(ctx context.Context, userId, communityId string) (*Member, error)
+		UpsertByUserIdAndCommunityId(ctx context.Context, userId, communityId string) error
+		DeleteByUserIdAndCommunityId(ctx context.Context, userId, communityId string) (int64, error)
+	}
+
+	CustomMemberModel struct {
+		*defaultMemberModel
+	}
+)
+
+func (m CustomMemberModel) FindOneByUserIdAndCommunityId(ctx context.Context, userId, communityId string) (*Member, error) {
+	var data Member
+	err := m.conn.FindOneNoCache(ctx, &data, bson.M{"userId": userId, "communityId": communityId})
+	switch err {
+	case nil:
+		return &data, nil
+	case monc.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
+
+// UpsertByUserIdAndCommunityId 在成员记录不存在时插入，并发添加时不会重复插入
+func (m CustomMemberModel) UpsertByUserIdAndCommunityId(ctx context.Context, userId, communityId string) error {
+	now := time.Now()
+	// 插入时 filter 中的等值条件会写入文档
+	update := bson.M{"$setOnInsert": bson.M{"createAt": now, "updateAt": now}}
+	filter := bson.M{"userId": userId, "communityId": communityId}
+	_, err := m.conn.UpdateOneNoCache(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// DeleteByUserIdAndCommunityId 删除所有相同的成员记录
+func (m CustomMemberModel) DeleteByUserIdAndCommunityId(ctx context.Context, userId, communityId string) (int64, error) {
+	return m.conn.DeleteMany(ctx, bson.M{"userId": userId, "communityId": communityId})
+}
+
+// NewMemberModel returns a model for the mongo.
+func NewMemberModel(url, db, collection string, c cache.CacheConf) MemberModel {
+	conn := monc.MustNewModel(url, db, collection, c)
+	return &CustomMemberModel{
+		defaultMemberModel: newDefaultMemberModel(conn),
+	}
+}
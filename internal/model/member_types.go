@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Member 是私有社区的成员
+type Member struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserId      string             `bson:"userId,omitempty" json:"userId,omitempty"`
+	CommunityId string             `bson:"communityId,omitempty" json:"communityId,omitempty"`
+	UpdateAt    time.Time          `bson:"updateAt,omitempty" json:"updateAt,omitempty"`
+	CreateAt    time.Time          `bson:"createAt,omitempty" json:"createAt,omitempty"`
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const ProfileCollectionName = "profile"
+
+var _ ProfileModel = (*CustomProfileModel)(nil)
+
+type (
+	// ProfileModel is an interface to be customized, add more methods here,
+	// and implement the added methods in CustomProfileModel.
+	ProfileModel interface {
+		profileModel
+		FindOneByUserId(ctx context.Context, userId string) (*Profile, error)
+	}
+
+	CustomProfileModel struct {
+		*defaultProfileModel
+	}
+)
+
+func (m CustomProfileModel) FindOneByUserId(ctx context.Context, userId string) (*Profile, error) {
+	var data Profile
+	err := m.conn.FindOneNoCache(ctx, &data, bson.M{"userId": userId})
+	switch err {
+	case nil:
+		return &data, nil
+	case monc.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
+
+// NewProfileModel returns a model for the mongo.
+func NewProfileModel(url, db, collection string, c cache.CacheConf) ProfileModel {
+	conn := monc.MustNewModel(url, db, collection, c)
+	return &CustomProfileModel{
+		defaultProfileModel: newDefaultProfileModel(conn),
+	}
+}
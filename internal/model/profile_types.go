@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Profile 是用户资料的可见性，没有记录的用户使用配置的默认可见性
+type Profile struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserId     string             `bson:"userId,omitempty" json:"userId,omitempty"`
+	Visibility string             `bson:"visibility,omitempty" json:"visibility,omitempty"`
+	UpdateAt   time.Time          `bson:"updateAt,omitempty" json:"updateAt,omitempty"`
+	CreateAt   time.Time          `bson:"createAt,omitempty" json:"createAt,omitempty"`
+}
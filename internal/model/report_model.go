@@ -0,0 +1,87 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const ReportCollectionName = "report"
+
+var _ ReportModel = (*CustomReportModel)(nil)
+
+type (
+	// ReportModel is an interface to be customized, add more methods here,
+	// and implement the added methods in CustomReportModel.
+	ReportModel interface {
+		reportModel
+		FindOneOpenByUserIdAndObject(ctx context.Context, userId, object, objectId string) (*Report, error)
+		UpsertOpenByUserIdAndObject(ctx context.Context, userId, object, objectId string) error
+		CloseByObject(ctx context.Context, object, objectId string) (int64, error)
+	}
+
+	CustomReportModel struct {
+		*defaultReportModel
+	}
+)
+
+// FindOneOpenByUserIdAndObject 查询用户对对象未处理的举报
+func (m CustomReportModel) FindOneOpenByUserIdAndObject(ctx context.Context, userId, object, objectId string) (*Report, error) {
+	var data Report
+	err := m.conn.FindOneNoCache(ctx, &data, bson.M{
+		"userId":   userId,
+		"object":   object,
+		"objectId": objectId,
+		"closed":   bson.M{"$ne": true},
+	})
+	switch err {
+	case nil:
+		return &data, nil
+	case monc.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
+
+// UpsertOpenByUserIdAndObject 在用户对对象没有未处理的举报时插入
+func (m CustomReportModel) UpsertOpenByUserIdAndObject(ctx context.Context, userId, object, objectId string) error {
+	now := time.Now()
+	// 插入时 filter 中的等值条件会写入文档，closed 不写入
+	update := bson.M{"$setOnInsert": bson.M{"createAt": now, "updateAt": now}}
+	filter := bson.M{
+		"userId":   userId,
+		"object":   object,
+		"objectId": objectId,
+		"closed":   bson.M{"$ne": true},
+	}
+	_, err := m.conn.UpdateOneNoCache(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// CloseByObject 处理对象的所有未处理的举报，返回处理的举报数
+func (m CustomReportModel) CloseByObject(ctx context.Context, object, objectId string) (int64, error) {
+	filter := bson.M{
+		"object":   object,
+		"objectId": objectId,
+		"closed":   bson.M{"$ne": true},
+	}
+	update := bson.M{"$set": bson.M{"closed": true, "updateAt": time.Now()}}
+	res, err := m.conn.UpdateManyNoCache(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// NewReportModel returns a model for the mongo.
+func NewReportModel(url, db, collection string, c cache.CacheConf) ReportModel {
+	conn := monc.MustNewModel(url, db, collection, c)
+	return &CustomReportModel{
+		defaultReportModel: newDefaultReportModel(conn),
+	}
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Report 表示 UserId 举报了对象，由 report 接口写入，closeReport 处理后 Closed 为 true
+type Report struct {
+	ID       primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserId   string             `bson:"userId,omitempty" json:"userId,omitempty"`
+	Object   string             `bson:"object,omitempty" json:"object,omitempty"`
+	ObjectId string             `bson:"objectId,omitempty" json:"objectId,omitempty"`
+	Closed   bool               `bson:"closed,omitempty" json:"closed,omitempty"`
+	UpdateAt time.Time          `bson:"updateAt,omitempty" json:"updateAt,omitempty"`
+	CreateAt time.Time          `bson:"createAt,omitempty" json:"createAt,omitempty"`
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const VisibilityCollectionName = "visibility"
+
+var _ VisibilityModel = (*CustomVisibilityModel)(nil)
+
+type (
+	// VisibilityModel is an interface to be customized, add more methods here,
+	// and implement the added methods in CustomVisibilityModel.
+	VisibilityModel interface {
+		visibilityModel
+		FindOneByCommunityId(ctx context.Context, communityId string) (*Visibility, error)
+	}
+
+	CustomVisibilityModel struct {
+		*defaultVisibilityModel
+	}
+)
+
+func (m CustomVisibilityModel) FindOneByCommunityId(ctx context.Context, communityId string) (*Visibility, error) {
+	var data Visibility
+	err := m.conn.FindOneNoCache(ctx, &data, bson.M{"communityId": communityId})
+	switch err {
+	case nil:
+		return &data, nil
+	case monc.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
+
+// NewVisibilityModel returns a model for the mongo.
+func NewVisibilityModel(url, db, collection string, c cache.CacheConf) VisibilityModel {
+	conn := monc.MustNewModel(url, db, collection, c)
+	return &CustomVisibilityModel{
+		defaultVisibilityModel: newDefaultVisibilityModel(conn),
+	}
+}
@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Visibility 是社区的可见性，没有记录的社区是公开的
+type Visibility struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	CommunityId string             `bson:"communityId,omitempty" json:"communityId,omitempty"`
+	Private     bool               `bson:"private,omitempty" json:"private,omitempty"`
+	UpdateAt    time.Time          `bson:"updateAt,omitempty" json:"updateAt,omitempty"`
+	CreateAt    time.Time          `bson:"createAt,omitempty" json:"createAt,omitempty"`
+}
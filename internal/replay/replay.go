@@ -0,0 +1,167 @@
+package replay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/fixture"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/logic"
+	"github.com/xh-polaris/meowchat-authorization-rpc/pb"
+	"github.com/zeromicro/go-zero/core/conf"
+)
+
+type (
+	// Record 是录制的一次 Allow 请求，Allow 为录制时的判定结果，作为比较的基准
+	Record struct {
+		UserId   string `json:"userId"`
+		ObjectId string `json:"objectId"`
+		Object   string `json:"object"`
+		Action   string `json:"action"`
+		// 新建评论时的从属对象
+		ParentObject string `json:"parentObject,omitempty"`
+		ParentId     string `json:"parentId,omitempty"`
+		// 修改用户角色时授予或撤销的角色
+		Role  *pb.Role `json:"role,omitempty"`
+		Allow *bool    `json:"allow,omitempty"`
+	}
+
+	// Result 是一条记录的重放结果
+	Result struct {
+		Record
+		Decision bool
+	}
+
+	Summary struct {
+		Total   int
+		Allowed int
+		Changed int
+	}
+
+	// 重放只需要配置文件中影响判定的部分
+	replayConf struct {
+		fixture.Settings
+	}
+)
+
+// Changed 判断重放结果是否与基准不同，没有基准时视为相同
+func (r Result) Changed() bool {
+	return r.Allow != nil && *r.Allow != r.Decision
+}
+
+// Run 执行 replay 子命令
+//  configFile 提供策略集和其他影响判定的配置，args 为子命令的参数
+func Run(configFile string, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	requests := fs.String("requests", "", "the json lines file of recorded AllowReq")
+	world := fs.String("fixture", "", "the fixture file of users, communities and contents")
+	diffOnly := fs.Bool("diff", false, "only report decisions that differ from the recorded ones")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *requests == "" || *world == "" {
+		return errors.New("replay: -requests and -fixture are required")
+	}
+
+	var c replayConf
+	if err := conf.Load(configFile, &c, conf.UseEnv()); err != nil {
+		return err
+	}
+	w, err := fixture.Load(*world)
+	if err != nil {
+		return err
+	}
+	records, err := readRecords(*requests)
+	if err != nil {
+		return err
+	}
+
+	results := Evaluate(c.Config(), w, records)
+	summary := Report(out, results, *diffOnly)
+	if summary.Changed > 0 {
+		return fmt.Errorf("replay: %d decisions changed", summary.Changed)
+	}
+	return nil
+}
+
+// Evaluate 使用 World 代替上游服务，在 c 的策略集上依次判定 records
+func Evaluate(c config.Config, w *fixture.World, records []Record) []Result {
+	svcCtx := fixture.NewServiceContext(c, w)
+	results := make([]Result, 0, len(records))
+	for _, r := range records {
+		resp, _ := logic.NewAllowLogic(context.Background(), svcCtx).Allow(&pb.AllowReq{
+			UserId:       r.UserId,
+			ObjectId:     r.ObjectId,
+			Object:       r.Object,
+			Action:       r.Action,
+			ParentObject: r.ParentObject,
+			ParentId:     r.ParentId,
+			Role:         r.Role,
+		})
+		results = append(results, Result{
+			Record:   r,
+			Decision: resp != nil && resp.Allow,
+		})
+	}
+	return results
+}
+
+// Report 输出重放结果和汇总
+func Report(out io.Writer, results []Result, diffOnly bool) Summary {
+	var s Summary
+	for _, r := range results {
+		s.Total++
+		if r.Decision {
+			s.Allowed++
+		}
+		if r.Changed() {
+			s.Changed++
+		}
+		if diffOnly && !r.Changed() {
+			continue
+		}
+
+		line := fmt.Sprintf("%-5s %s %s:%s user=%q", decision(r.Decision), r.Action, r.Object, r.ObjectId, r.UserId)
+		if r.Changed() {
+			line += fmt.Sprintf(" (recorded %s)", decision(*r.Allow))
+		}
+		fmt.Fprintln(out, line)
+	}
+	fmt.Fprintf(out, "total %d, allowed %d, denied %d, changed %d\n", s.Total, s.Allowed, s.Total-s.Allowed, s.Changed)
+	return s
+}
+
+func readRecords(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("replay: %s:%d: %w", path, line, err)
+		}
+		records = append(records, r)
+	}
+	return records, scanner.Err()
+}
+
+func decision(allow bool) string {
+	if allow {
+		return "allow"
+	}
+	return "deny"
+}
@@ -0,0 +1,87 @@
+package replay
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/fixture"
+	"github.com/zeromicro/go-zero/core/conf"
+)
+
+func TestEvaluate(t *testing.T) {
+	w, err := fixture.Load("testdata/world.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := readRecords("testdata/requests.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("内置策略与录制结果一致", t, func() {
+		results := Evaluate(config.Config{}, w, records)
+		So(results, ShouldHaveLength, len(records))
+		for _, r := range results {
+			So(r.Changed(), ShouldBeFalse)
+		}
+		So(results[len(results)-1].Decision, ShouldBeTrue)
+	})
+
+	Convey("报告与基准不同的结果", t, func() {
+		deny := false
+		results := Evaluate(config.Config{}, w, []Record{{
+			UserId:   "alice",
+			ObjectId: "post1",
+			Object:   ObjectPost,
+			Action:   ActionWrite,
+			Allow:    &deny,
+		}})
+		var out bytes.Buffer
+		summary := Report(&out, results, true)
+		So(summary.Changed, ShouldEqual, 1)
+		So(out.String(), ShouldContainSubstring, "(recorded deny)")
+	})
+}
+
+func TestRun(t *testing.T) {
+	Convey("rego 策略与录制结果一致", t, func() {
+		var out bytes.Buffer
+		err := Run("testdata/replay.yaml", []string{
+			"-requests", "testdata/requests.jsonl",
+			"-fixture", "testdata/world.yaml",
+		}, &out)
+		So(err, ShouldBeNil)
+		So(out.String(), ShouldContainSubstring, "total 8, allowed 6, denied 2, changed 0")
+	})
+
+	Convey("加载策略集以外影响判定的配置", t, func() {
+		var c replayConf
+		So(conf.Load("testdata/restricted.yaml", &c), ShouldBeNil)
+		cfg := c.Config()
+		So(cfg.Anonymous.Rules, ShouldHaveLength, 1)
+		So(cfg.PrivateCommunity, ShouldBeTrue)
+		So(cfg.ContentStatus.Hidden, ShouldResemble, []int64{2})
+		So(cfg.EditWindows, ShouldHaveLength, 1)
+		So(cfg.UserProfile.Visibility, ShouldEqual, ProfilePrivate)
+		So(cfg.Interaction.NoSelfLike, ShouldResemble, []string{ObjectPost})
+
+		w, err := fixture.Load("testdata/world.yaml")
+		So(err, ShouldBeNil)
+		results := Evaluate(cfg, w, []Record{
+			{Object: ObjectPost, ObjectId: "post1", Action: ActionRead},
+			{UserId: "bob", Object: ObjectUser, ObjectId: "alice", Action: ActionRead},
+			{UserId: "alice", Object: ObjectLike, Action: ActionWrite, ParentObject: ObjectPost, ParentId: "post1"},
+		})
+		for _, r := range results {
+			So(r.Decision, ShouldBeFalse)
+		}
+	})
+
+	Convey("缺少参数", t, func() {
+		var out bytes.Buffer
+		So(Run("testdata/replay.yaml", nil, &out), ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,61 @@
+package resolver
+
+import "context"
+
+type (
+	// Resource 是判定需要的对象属性，与对象类型和提供它的上游服务无关
+	Resource struct {
+		Id string
+		// 发布者，没有时为空
+		OwnerId string
+		// 所属社区，社区的所属社区是其父社区，没有时为空
+		CommunityId string
+		// 从属对象，如评论所在的帖子或动态，没有时为空
+		ParentType string
+		ParentId   string
+		// 内容状态，只有帖子有
+		Status int64
+		// 发布时间，unix 秒
+		CreateAt int64
+	}
+
+	// ResourceResolver 查询一种对象的属性
+	//  对象不存在时返回 nil，上游服务的错误原样返回，由调用方判断是否降级
+	ResourceResolver interface {
+		Resolve(ctx context.Context, id string) (*Resource, error)
+	}
+
+	// ResolverFunc 把函数适配为 ResourceResolver
+	ResolverFunc func(ctx context.Context, id string) (*Resource, error)
+
+	// Registry 按对象类型注册 ResourceResolver
+	//  注册了解析器但没有内置策略的对象类型使用通用策略判定，新的服务只需注册解析器
+	Registry struct {
+		resolvers map[string]ResourceResolver
+	}
+)
+
+func (f ResolverFunc) Resolve(ctx context.Context, id string) (*Resource, error) {
+	return f(ctx, id)
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		resolvers: make(map[string]ResourceResolver),
+	}
+}
+
+// Register 注册对象类型的解析器，重复注册时覆盖之前的解析器
+//  只在启动时调用，判定期间不能注册
+func (r *Registry) Register(object string, resolver ResourceResolver) {
+	r.resolvers[object] = resolver
+}
+
+// Get 返回对象类型的解析器
+func (r *Registry) Get(object string) (ResourceResolver, bool) {
+	if r == nil {
+		return nil, false
+	}
+	resolver, ok := r.resolvers[object]
+	return resolver, ok
+}
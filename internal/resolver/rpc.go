@@ -0,0 +1,151 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/xh-polaris/meowchat-collection-rpc/collectionrpc"
+	cat "github.com/xh-polaris/meowchat-collection-rpc/pb"
+	"github.com/xh-polaris/meowchat-comment-rpc/commentrpc"
+	comment "github.com/xh-polaris/meowchat-comment-rpc/pb"
+	"github.com/xh-polaris/meowchat-moment-rpc/momentrpc"
+	moment "github.com/xh-polaris/meowchat-moment-rpc/pb"
+	post "github.com/xh-polaris/meowchat-post-rpc/pb"
+	"github.com/xh-polaris/meowchat-post-rpc/postrpc"
+	system "github.com/xh-polaris/meowchat-system-rpc/pb"
+	"github.com/xh-polaris/meowchat-system-rpc/systemrpc"
+)
+
+// 内置对象类型的解析器，每次解析最多调用一次上游服务
+
+// NewCommunityResolver 使用 system-rpc 解析社区，所属社区为父社区
+func NewCommunityResolver(client systemrpc.SystemRpc) ResourceResolver {
+	return ResolverFunc(func(ctx context.Context, id string) (*Resource, error) {
+		c, err := client.RetrieveCommunity(ctx, &system.RetrieveCommunityReq{Id: id})
+		if c == nil || c.Community == nil {
+			return nil, err
+		}
+		return &Resource{
+			Id:          id,
+			CommunityId: c.Community.ParentId,
+		}, nil
+	})
+}
+
+// NewNoticeResolver 使用 system-rpc 解析通知
+func NewNoticeResolver(client systemrpc.SystemRpc) ResourceResolver {
+	return ResolverFunc(func(ctx context.Context, id string) (*Resource, error) {
+		n, err := client.RetrieveNotice(ctx, &system.RetrieveNoticeReq{Id: id})
+		if n == nil || n.Notice == nil {
+			return nil, err
+		}
+		return &Resource{
+			Id:          id,
+			CommunityId: n.Notice.CommunityId,
+		}, nil
+	})
+}
+
+// NewNewsResolver 使用 system-rpc 解析轮播图
+func NewNewsResolver(client systemrpc.SystemRpc) ResourceResolver {
+	return ResolverFunc(func(ctx context.Context, id string) (*Resource, error) {
+		n, err := client.RetrieveNews(ctx, &system.RetrieveNewsReq{Id: id})
+		if n == nil || n.News == nil {
+			return nil, err
+		}
+		return &Resource{
+			Id:          id,
+			CommunityId: n.News.CommunityId,
+		}, nil
+	})
+}
+
+// NewAdminResolver 使用 system-rpc 解析社区管理员信息
+func NewAdminResolver(client systemrpc.SystemRpc) ResourceResolver {
+	return ResolverFunc(func(ctx context.Context, id string) (*Resource, error) {
+		a, err := client.RetrieveAdmin(ctx, &system.RetrieveAdminReq{Id: id})
+		if a == nil || a.Admin == nil {
+			return nil, err
+		}
+		return &Resource{
+			Id:          id,
+			CommunityId: a.Admin.CommunityId,
+		}, nil
+	})
+}
+
+// NewCatResolver 使用 collection-rpc 解析猫咪
+func NewCatResolver(client collectionrpc.CollectionRpc) ResourceResolver {
+	return ResolverFunc(func(ctx context.Context, id string) (*Resource, error) {
+		c, err := client.RetrieveCat(ctx, &cat.RetrieveCatReq{CatId: id})
+		if c == nil || c.Cat == nil {
+			return nil, err
+		}
+		return &Resource{
+			Id:          id,
+			CommunityId: c.Cat.CommunityId,
+		}, nil
+	})
+}
+
+// NewPostResolver 使用 post-rpc 解析帖子，帖子不属于社区
+func NewPostResolver(client postrpc.PostRpc) ResourceResolver {
+	return ResolverFunc(func(ctx context.Context, id string) (*Resource, error) {
+		p, err := client.RetrievePost(ctx, &post.RetrievePostReq{PostId: id})
+		if p == nil || p.Post == nil {
+			return nil, err
+		}
+		return &Resource{
+			Id:       id,
+			OwnerId:  p.Post.UserId,
+			Status:   p.Post.Status,
+			CreateAt: p.Post.CreateAt,
+		}, nil
+	})
+}
+
+// NewMomentResolver 使用 moment-rpc 解析动态
+func NewMomentResolver(client momentrpc.MomentRpc) ResourceResolver {
+	return ResolverFunc(func(ctx context.Context, id string) (*Resource, error) {
+		m, err := client.RetrieveMoment(ctx, &moment.RetrieveMomentReq{MomentId: id})
+		if m == nil || m.Moment == nil {
+			return nil, err
+		}
+		return &Resource{
+			Id:          id,
+			OwnerId:     m.Moment.UserId,
+			CommunityId: m.Moment.CommunityId,
+			CreateAt:    m.Moment.CreateAt,
+		}, nil
+	})
+}
+
+// NewCommentResolver 使用 comment-rpc 解析评论，评论的所属社区是从属对象的社区，需要调用方解析
+func NewCommentResolver(client commentrpc.CommentRpc) ResourceResolver {
+	return ResolverFunc(func(ctx context.Context, id string) (*Resource, error) {
+		c, err := client.RetrieveCommentById(ctx, &comment.RetrieveCommentByIdRequest{Id: id})
+		if c == nil || c.Comment == nil {
+			return nil, err
+		}
+		return &Resource{
+			Id:         id,
+			OwnerId:    c.Comment.AuthorId,
+			ParentType: c.Comment.Type,
+			ParentId:   c.Comment.ParentId,
+			CreateAt:   c.Comment.CreateAt,
+		}, nil
+	})
+}
+
+// NewUserResolver 解析用户，用户是自己的发布者
+//  没有用户服务可以查询，不检查用户是否存在
+func NewUserResolver() ResourceResolver {
+	return ResolverFunc(func(_ context.Context, id string) (*Resource, error) {
+		if id == "" {
+			return nil, nil
+		}
+		return &Resource{
+			Id:      id,
+			OwnerId: id,
+		}, nil
+	})
+}
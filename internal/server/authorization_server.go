@@ -26,3 +26,78 @@ func (s *AuthorizationServer) Allow(ctx context.Context, in *pb.AllowReq) (*pb.A
 	l := logic.NewAllowLogic(ctx, s.svcCtx)
 	return l.Allow(in)
 }
+
+func (s *AuthorizationServer) Block(ctx context.Context, in *pb.BlockReq) (*pb.BlockResp, error) {
+	l := logic.NewBlockLogic(ctx, s.svcCtx)
+	return l.Block(in)
+}
+
+func (s *AuthorizationServer) Unblock(ctx context.Context, in *pb.UnblockReq) (*pb.UnblockResp, error) {
+	l := logic.NewUnblockLogic(ctx, s.svcCtx)
+	return l.Unblock(in)
+}
+
+func (s *AuthorizationServer) ListBlock(ctx context.Context, in *pb.ListBlockReq) (*pb.ListBlockResp, error) {
+	l := logic.NewListBlockLogic(ctx, s.svcCtx)
+	return l.ListBlock(in)
+}
+
+func (s *AuthorizationServer) Lock(ctx context.Context, in *pb.LockReq) (*pb.LockResp, error) {
+	l := logic.NewLockLogic(ctx, s.svcCtx)
+	return l.Lock(in)
+}
+
+func (s *AuthorizationServer) Unlock(ctx context.Context, in *pb.UnlockReq) (*pb.UnlockResp, error) {
+	l := logic.NewUnlockLogic(ctx, s.svcCtx)
+	return l.Unlock(in)
+}
+
+func (s *AuthorizationServer) SetProfileVisibility(ctx context.Context, in *pb.SetProfileVisibilityReq) (*pb.SetProfileVisibilityResp, error) {
+	l := logic.NewSetProfileVisibilityLogic(ctx, s.svcCtx)
+	return l.SetProfileVisibility(in)
+}
+
+func (s *AuthorizationServer) SetCommunityVisibility(ctx context.Context, in *pb.SetCommunityVisibilityReq) (*pb.SetCommunityVisibilityResp, error) {
+	l := logic.NewSetCommunityVisibilityLogic(ctx, s.svcCtx)
+	return l.SetCommunityVisibility(in)
+}
+
+func (s *AuthorizationServer) AddMember(ctx context.Context, in *pb.AddMemberReq) (*pb.AddMemberResp, error) {
+	l := logic.NewAddMemberLogic(ctx, s.svcCtx)
+	return l.AddMember(in)
+}
+
+func (s *AuthorizationServer) RemoveMember(ctx context.Context, in *pb.RemoveMemberReq) (*pb.RemoveMemberResp, error) {
+	l := logic.NewRemoveMemberLogic(ctx, s.svcCtx)
+	return l.RemoveMember(in)
+}
+
+func (s *AuthorizationServer) Grant(ctx context.Context, in *pb.GrantReq) (*pb.GrantResp, error) {
+	l := logic.NewGrantLogic(ctx, s.svcCtx)
+	return l.Grant(in)
+}
+
+func (s *AuthorizationServer) Revoke(ctx context.Context, in *pb.RevokeReq) (*pb.RevokeResp, error) {
+	l := logic.NewRevokeLogic(ctx, s.svcCtx)
+	return l.Revoke(in)
+}
+
+func (s *AuthorizationServer) Report(ctx context.Context, in *pb.ReportReq) (*pb.ReportResp, error) {
+	l := logic.NewReportLogic(ctx, s.svcCtx)
+	return l.Report(in)
+}
+
+func (s *AuthorizationServer) CloseReport(ctx context.Context, in *pb.CloseReportReq) (*pb.CloseReportResp, error) {
+	l := logic.NewCloseReportLogic(ctx, s.svcCtx)
+	return l.CloseReport(in)
+}
+
+func (s *AuthorizationServer) Follow(ctx context.Context, in *pb.FollowReq) (*pb.FollowResp, error) {
+	l := logic.NewFollowLogic(ctx, s.svcCtx)
+	return l.Follow(in)
+}
+
+func (s *AuthorizationServer) Unfollow(ctx context.Context, in *pb.UnfollowReq) (*pb.UnfollowResp, error) {
+	l := logic.NewUnfollowLogic(ctx, s.svcCtx)
+	return l.Unfollow(in)
+}
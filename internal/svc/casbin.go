@@ -0,0 +1,51 @@
+package svc
+
+import (
+	"errors"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/util"
+)
+
+var errCasbinRoleDefinition = errors.New("casbin model must not define role_definition, roles are resolved per request")
+
+// CasbinEnforcer 是策略集共用的 casbin 执行器，规则只在创建时加载一次
+//  模型只匹配 p 规则，请求的 sub 是角色，判定时不修改执行器，可以并发判定
+type CasbinEnforcer struct {
+	e *casbin.Enforcer
+}
+
+// CasbinRole 是用户在域下拥有的角色，域为 "*" 时匹配任意域
+type CasbinRole struct {
+	Role   string
+	Domain string
+}
+
+func newCasbinEnforcer(m model.Model) (*CasbinEnforcer, error) {
+	// 角色关系由每次判定的用户决定，不能写进共用的执行器
+	if _, ok := m["g"]; ok {
+		return nil, errCasbinRoleDefinition
+	}
+	e, err := casbin.NewEnforcer(m)
+	if err != nil {
+		return nil, err
+	}
+	return &CasbinEnforcer{e: e}, nil
+}
+
+// Enforce 在 domains 中任一域下，用户在该域拥有的任一角色允许时返回 true
+func (c *CasbinEnforcer) Enforce(roles []CasbinRole, domains []string, obj, act string) (bool, error) {
+	for _, domain := range domains {
+		for _, r := range roles {
+			if !util.KeyMatch(domain, r.Domain) {
+				continue
+			}
+			ok, err := c.e.Enforce(r.Role, domain, obj, act)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,82 @@
+package svc
+
+import (
+	"context"
+
+	"github.com/casbin/casbin/v2/model"
+	fileadapter "github.com/casbin/casbin/v2/persist/file-adapter"
+	"github.com/open-policy-agent/opa/rego"
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// Policy 是根据 PolicyConf 初始化好的策略集
+type Policy struct {
+	Engine string
+	// 交给非内置引擎判定的对象类型，为空时表示全部
+	Objects map[string]bool
+	Rego    *rego.PreparedEvalQuery
+	// 已加载 p 规则的 casbin 执行器
+	Casbin *CasbinEnforcer
+}
+
+func MustNewPolicy(c config.PolicyConf) *Policy {
+	p := &Policy{Engine: c.Engine}
+	switch c.Engine {
+	case config.EngineRego:
+		q, err := rego.New(
+			rego.Query(c.Rego.Query),
+			rego.Load([]string{c.Rego.Path}, nil),
+		).PrepareForEval(context.Background())
+		logx.Must(err)
+		p.Rego = &q
+		p.Objects = objectSet(c.Rego.Objects)
+	case config.EngineCasbin:
+		m, err := model.NewModelFromFile(c.Casbin.Model)
+		logx.Must(err)
+		logx.Must(fileadapter.NewAdapter(c.Casbin.Policy).LoadPolicy(m))
+		p.Casbin, err = newCasbinEnforcer(m)
+		logx.Must(err)
+		p.Objects = objectSet(c.Casbin.Objects)
+	}
+	return p
+}
+
+// 未配置影子策略集时返回nil
+func mustNewShadowPolicy(c config.PolicyConf) *Policy {
+	if c.Engine == "" {
+		return nil
+	}
+	return MustNewPolicy(c)
+}
+
+// 始终由内置策略判定的对象类型
+//  拉黑、关注和举报记录只有本服务能查询，外部引擎无法表达这些规则，评论的新建和修改都受拉黑限制；
+//  用户角色关系到权限提升，不能被宽松的外部策略放开
+var builtinObjects = map[string]bool{
+	ObjectRole:    true,
+	ObjectComment: true,
+	ObjectLike:    true,
+	ObjectFollow:  true,
+	ObjectReport:  true,
+}
+
+// Uses 判断对象类型是否由指定引擎判定
+func (p *Policy) Uses(engine, object string) bool {
+	if p == nil || p.Engine != engine || builtinObjects[object] {
+		return false
+	}
+	return len(p.Objects) == 0 || p.Objects[object]
+}
+
+func objectSet(objects []string) map[string]bool {
+	if len(objects) == 0 {
+		return nil
+	}
+	s := make(map[string]bool, len(objects))
+	for _, o := range objects {
+		s[o] = true
+	}
+	return s
+}
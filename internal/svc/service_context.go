@@ -1,13 +1,20 @@
 package svc
 
 import (
+	. "github.com/xh-polaris/meowchat-authorization-rpc/constant"
 	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/interceptor"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/model"
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/resolver"
 	"github.com/xh-polaris/meowchat-collection-rpc/collectionrpc"
 	"github.com/xh-polaris/meowchat-comment-rpc/commentrpc"
 	"github.com/xh-polaris/meowchat-moment-rpc/momentrpc"
 	"github.com/xh-polaris/meowchat-post-rpc/postrpc"
 	"github.com/xh-polaris/meowchat-system-rpc/systemrpc"
+	"github.com/zeromicro/go-zero/core/collection"
+	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/zrpc"
+	"google.golang.org/grpc/connectivity"
 )
 
 type ServiceContext struct {
@@ -17,15 +24,112 @@ type ServiceContext struct {
 	SystemRPC     systemrpc.SystemRpc
 	CommentRPC    commentrpc.CommentRpc
 	PostRPC       postrpc.PostRpc
+	Policy        *Policy
+	Shadow        *Shadow
+	GrantModel    model.GrantModel
+	// 私有社区的可见性和成员
+	VisibilityModel model.VisibilityModel
+	MemberModel     model.MemberModel
+	BlockModel      model.BlockModel
+	LockModel       model.LockModel
+	// 用户资料的可见性和用户的关注关系
+	ProfileModel model.ProfileModel
+	FollowModel  model.FollowModel
+	// 用户的举报，用于限制重复举报
+	ReportModel model.ReportModel
+	// 最近一次正常判定的结果，只在降级方式包含 stale 时保留
+	Decisions *collection.Cache
+	// 上游服务的连接和熔断器，用于健康检查
+	Upstreams Upstreams
+	// 各对象类型的属性解析器
+	Resolvers *resolver.Registry
 }
 
+// Upstream 是一个上游服务的客户端
+type Upstream struct {
+	Name    string
+	Client  zrpc.Client
+	Breaker *interceptor.Breaker
+}
+
+type Upstreams []*Upstream
+
 func NewServiceContext(c config.Config) *ServiceContext {
-	return &ServiceContext{
+	var decisions *collection.Cache
+	if c.Degrade.UsesStale() {
+		var err error
+		decisions, err = collection.NewCache(c.Degrade.StaleTTL, collection.WithLimit(c.Degrade.StaleLimit),
+			collection.WithName("decision"))
+		logx.Must(err)
+	}
+
+	var upstreams Upstreams
+	svcCtx := &ServiceContext{
 		Config:        c,
-		CollectionRPC: collectionrpc.NewCollectionRpc(zrpc.MustNewClient(c.CollectionRPC)),
-		MomentRPC:     momentrpc.NewMomentRpc(zrpc.MustNewClient(c.MomentRPC)),
-		SystemRPC:     systemrpc.NewSystemRpc(zrpc.MustNewClient(c.SystemRPC)),
-		CommentRPC:    commentrpc.NewCommentRpc(zrpc.MustNewClient(c.CommentRPC)),
-		PostRPC:       postrpc.NewPostRpc(zrpc.MustNewClient(c.PostRPC)),
+		CollectionRPC: collectionrpc.NewCollectionRpc(upstreams.client(c, config.UpstreamCollection, c.CollectionRPC)),
+		MomentRPC:     momentrpc.NewMomentRpc(upstreams.client(c, config.UpstreamMoment, c.MomentRPC)),
+		SystemRPC:     systemrpc.NewSystemRpc(upstreams.client(c, config.UpstreamSystem, c.SystemRPC)),
+		CommentRPC:    commentrpc.NewCommentRpc(upstreams.client(c, config.UpstreamComment, c.CommentRPC)),
+		PostRPC:       postrpc.NewPostRpc(upstreams.client(c, config.UpstreamPost, c.PostRPC)),
+		Policy:        MustNewPolicy(c.Policy),
+		Shadow:        NewShadow(c.Shadow, mustNewShadowPolicy(c.ShadowPolicy)),
+		GrantModel:    model.NewGrantModel(c.Mongo.URL, c.Mongo.DB, model.GrantCollectionName, c.CacheConf),
+		VisibilityModel: model.NewVisibilityModel(c.Mongo.URL, c.Mongo.DB, model.VisibilityCollectionName,
+			c.CacheConf),
+		MemberModel: model.NewMemberModel(c.Mongo.URL, c.Mongo.DB, model.MemberCollectionName, c.CacheConf),
+		BlockModel:  model.NewBlockModel(c.Mongo.URL, c.Mongo.DB, model.BlockCollectionName, c.CacheConf),
+		LockModel:   model.NewLockModel(c.Mongo.URL, c.Mongo.DB, model.LockCollectionName, c.CacheConf),
+		ProfileModel: model.NewProfileModel(c.Mongo.URL, c.Mongo.DB, model.ProfileCollectionName,
+			c.CacheConf),
+		FollowModel: model.NewFollowModel(c.Mongo.URL, c.Mongo.DB, model.FollowCollectionName, c.CacheConf),
+		ReportModel: model.NewReportModel(c.Mongo.URL, c.Mongo.DB, model.ReportCollectionName, c.CacheConf),
+		Decisions:   decisions,
+	}
+	svcCtx.Upstreams = upstreams
+	svcCtx.Resolvers = NewResolvers(svcCtx)
+	return svcCtx
+}
+
+// NewResolvers 使用上游服务的客户端注册内置对象类型的解析器
+//  新的对象类型在返回的 Registry 上注册自己的解析器即可
+func NewResolvers(s *ServiceContext) *resolver.Registry {
+	r := resolver.NewRegistry()
+	r.Register(ObjectCommunity, resolver.NewCommunityResolver(s.SystemRPC))
+	r.Register(ObjectNotice, resolver.NewNoticeResolver(s.SystemRPC))
+	r.Register(ObjectNews, resolver.NewNewsResolver(s.SystemRPC))
+	r.Register(ObjectAdmin, resolver.NewAdminResolver(s.SystemRPC))
+	r.Register(ObjectCat, resolver.NewCatResolver(s.CollectionRPC))
+	r.Register(ObjectPost, resolver.NewPostResolver(s.PostRPC))
+	r.Register(ObjectMoment, resolver.NewMomentResolver(s.MomentRPC))
+	r.Register(ObjectComment, resolver.NewCommentResolver(s.CommentRPC))
+	r.Register(ObjectUser, resolver.NewUserResolver())
+	return r
+}
+
+// 创建上游服务的客户端，每个上游服务使用单独的熔断器
+func (u *Upstreams) client(c config.Config, name string, rc zrpc.RpcClientConf) zrpc.Client {
+	breaker := interceptor.MustNewBreaker(name, c.Breakers.Of(name))
+	client := zrpc.MustNewClient(rc, zrpc.WithUnaryClientInterceptor(breaker.UnaryClientInterceptor))
+	*u = append(*u, &Upstream{
+		Name:    name,
+		Client:  client,
+		Breaker: breaker,
+	})
+	return client
+}
+
+// Healthy 判断上游服务是否可用，熔断中或连接失败时不可用
+func (u *Upstream) Healthy() bool {
+	if u.Breaker != nil && u.Breaker.Open() {
+		return false
+	}
+	if u.Client == nil {
+		return true
+	}
+	switch u.Client.Conn().GetState() {
+	case connectivity.TransientFailure, connectivity.Shutdown:
+		return false
+	default:
+		return true
 	}
 }
@@ -0,0 +1,68 @@
+package svc
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/xh-polaris/meowchat-authorization-rpc/internal/config"
+	"github.com/zeromicro/go-zero/core/threading"
+)
+
+// Shadow 在固定数量的协程上异步判定影子策略集
+//  未抽中或队列已满的请求直接丢弃，不会阻塞生效策略的判定
+type Shadow struct {
+	Policy *Policy
+	// 单次判定的超时
+	Timeout time.Duration
+	rate    float64
+	tasks   chan func()
+}
+
+// NewShadow 启动判定影子策略集的协程，未配置影子策略集时返回nil
+func NewShadow(c config.ShadowConf, p *Policy) *Shadow {
+	if p == nil {
+		return nil
+	}
+	if c.Workers <= 0 {
+		c.Workers = 1
+	}
+	if c.QueueSize < 0 {
+		c.QueueSize = 0
+	}
+	if c.SampleRate <= 0 {
+		c.SampleRate = 1
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = time.Second
+	}
+
+	s := &Shadow{
+		Policy:  p,
+		Timeout: c.Timeout,
+		rate:    c.SampleRate,
+		tasks:   make(chan func(), c.QueueSize),
+	}
+	for i := 0; i < c.Workers; i++ {
+		threading.GoSafe(func() {
+			for task := range s.tasks {
+				threading.RunSafe(task)
+			}
+		})
+	}
+	return s
+}
+
+// Sample 按抽样比例判断是否判定本次请求
+func (s *Shadow) Sample() bool {
+	return s.rate >= 1 || rand.Float64() < s.rate
+}
+
+// Submit 把判定放入队列，队列已满时返回 false
+func (s *Shadow) Submit(task func()) bool {
+	select {
+	case s.tasks <- task:
+		return true
+	default:
+		return false
+	}
+}
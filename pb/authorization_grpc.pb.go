@@ -23,6 +23,21 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type AuthorizationClient interface {
 	Allow(ctx context.Context, in *AllowReq, opts ...grpc.CallOption) (*AllowResp, error)
+	Block(ctx context.Context, in *BlockReq, opts ...grpc.CallOption) (*BlockResp, error)
+	Unblock(ctx context.Context, in *UnblockReq, opts ...grpc.CallOption) (*UnblockResp, error)
+	ListBlock(ctx context.Context, in *ListBlockReq, opts ...grpc.CallOption) (*ListBlockResp, error)
+	Lock(ctx context.Context, in *LockReq, opts ...grpc.CallOption) (*LockResp, error)
+	Unlock(ctx context.Context, in *UnlockReq, opts ...grpc.CallOption) (*UnlockResp, error)
+	SetProfileVisibility(ctx context.Context, in *SetProfileVisibilityReq, opts ...grpc.CallOption) (*SetProfileVisibilityResp, error)
+	SetCommunityVisibility(ctx context.Context, in *SetCommunityVisibilityReq, opts ...grpc.CallOption) (*SetCommunityVisibilityResp, error)
+	AddMember(ctx context.Context, in *AddMemberReq, opts ...grpc.CallOption) (*AddMemberResp, error)
+	RemoveMember(ctx context.Context, in *RemoveMemberReq, opts ...grpc.CallOption) (*RemoveMemberResp, error)
+	Grant(ctx context.Context, in *GrantReq, opts ...grpc.CallOption) (*GrantResp, error)
+	Revoke(ctx context.Context, in *RevokeReq, opts ...grpc.CallOption) (*RevokeResp, error)
+	Report(ctx context.Context, in *ReportReq, opts ...grpc.CallOption) (*ReportResp, error)
+	CloseReport(ctx context.Context, in *CloseReportReq, opts ...grpc.CallOption) (*CloseReportResp, error)
+	Follow(ctx context.Context, in *FollowReq, opts ...grpc.CallOption) (*FollowResp, error)
+	Unfollow(ctx context.Context, in *UnfollowReq, opts ...grpc.CallOption) (*UnfollowResp, error)
 }
 
 type authorizationClient struct {
@@ -42,11 +57,161 @@ func (c *authorizationClient) Allow(ctx context.Context, in *AllowReq, opts ...g
 	return out, nil
 }
 
+func (c *authorizationClient) Block(ctx context.Context, in *BlockReq, opts ...grpc.CallOption) (*BlockResp, error) {
+	out := new(BlockResp)
+	err := c.cc.Invoke(ctx, "/authorization.authorization/block", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationClient) Unblock(ctx context.Context, in *UnblockReq, opts ...grpc.CallOption) (*UnblockResp, error) {
+	out := new(UnblockResp)
+	err := c.cc.Invoke(ctx, "/authorization.authorization/unblock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationClient) ListBlock(ctx context.Context, in *ListBlockReq, opts ...grpc.CallOption) (*ListBlockResp, error) {
+	out := new(ListBlockResp)
+	err := c.cc.Invoke(ctx, "/authorization.authorization/listBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationClient) Lock(ctx context.Context, in *LockReq, opts ...grpc.CallOption) (*LockResp, error) {
+	out := new(LockResp)
+	err := c.cc.Invoke(ctx, "/authorization.authorization/lock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationClient) Unlock(ctx context.Context, in *UnlockReq, opts ...grpc.CallOption) (*UnlockResp, error) {
+	out := new(UnlockResp)
+	err := c.cc.Invoke(ctx, "/authorization.authorization/unlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationClient) SetProfileVisibility(ctx context.Context, in *SetProfileVisibilityReq, opts ...grpc.CallOption) (*SetProfileVisibilityResp, error) {
+	out := new(SetProfileVisibilityResp)
+	err := c.cc.Invoke(ctx, "/authorization.authorization/setProfileVisibility", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationClient) SetCommunityVisibility(ctx context.Context, in *SetCommunityVisibilityReq, opts ...grpc.CallOption) (*SetCommunityVisibilityResp, error) {
+	out := new(SetCommunityVisibilityResp)
+	err := c.cc.Invoke(ctx, "/authorization.authorization/setCommunityVisibility", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationClient) AddMember(ctx context.Context, in *AddMemberReq, opts ...grpc.CallOption) (*AddMemberResp, error) {
+	out := new(AddMemberResp)
+	err := c.cc.Invoke(ctx, "/authorization.authorization/addMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationClient) RemoveMember(ctx context.Context, in *RemoveMemberReq, opts ...grpc.CallOption) (*RemoveMemberResp, error) {
+	out := new(RemoveMemberResp)
+	err := c.cc.Invoke(ctx, "/authorization.authorization/removeMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationClient) Grant(ctx context.Context, in *GrantReq, opts ...grpc.CallOption) (*GrantResp, error) {
+	out := new(GrantResp)
+	err := c.cc.Invoke(ctx, "/authorization.authorization/grant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationClient) Revoke(ctx context.Context, in *RevokeReq, opts ...grpc.CallOption) (*RevokeResp, error) {
+	out := new(RevokeResp)
+	err := c.cc.Invoke(ctx, "/authorization.authorization/revoke", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationClient) Report(ctx context.Context, in *ReportReq, opts ...grpc.CallOption) (*ReportResp, error) {
+	out := new(ReportResp)
+	err := c.cc.Invoke(ctx, "/authorization.authorization/report", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationClient) CloseReport(ctx context.Context, in *CloseReportReq, opts ...grpc.CallOption) (*CloseReportResp, error) {
+	out := new(CloseReportResp)
+	err := c.cc.Invoke(ctx, "/authorization.authorization/closeReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationClient) Follow(ctx context.Context, in *FollowReq, opts ...grpc.CallOption) (*FollowResp, error) {
+	out := new(FollowResp)
+	err := c.cc.Invoke(ctx, "/authorization.authorization/follow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationClient) Unfollow(ctx context.Context, in *UnfollowReq, opts ...grpc.CallOption) (*UnfollowResp, error) {
+	out := new(UnfollowResp)
+	err := c.cc.Invoke(ctx, "/authorization.authorization/unfollow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthorizationServer is the server API for Authorization service.
 // All implementations must embed UnimplementedAuthorizationServer
 // for forward compatibility
 type AuthorizationServer interface {
 	Allow(context.Context, *AllowReq) (*AllowResp, error)
+	Block(context.Context, *BlockReq) (*BlockResp, error)
+	Unblock(context.Context, *UnblockReq) (*UnblockResp, error)
+	ListBlock(context.Context, *ListBlockReq) (*ListBlockResp, error)
+	Lock(context.Context, *LockReq) (*LockResp, error)
+	Unlock(context.Context, *UnlockReq) (*UnlockResp, error)
+	SetProfileVisibility(context.Context, *SetProfileVisibilityReq) (*SetProfileVisibilityResp, error)
+	SetCommunityVisibility(context.Context, *SetCommunityVisibilityReq) (*SetCommunityVisibilityResp, error)
+	AddMember(context.Context, *AddMemberReq) (*AddMemberResp, error)
+	RemoveMember(context.Context, *RemoveMemberReq) (*RemoveMemberResp, error)
+	Grant(context.Context, *GrantReq) (*GrantResp, error)
+	Revoke(context.Context, *RevokeReq) (*RevokeResp, error)
+	Report(context.Context, *ReportReq) (*ReportResp, error)
+	CloseReport(context.Context, *CloseReportReq) (*CloseReportResp, error)
+	Follow(context.Context, *FollowReq) (*FollowResp, error)
+	Unfollow(context.Context, *UnfollowReq) (*UnfollowResp, error)
 	mustEmbedUnimplementedAuthorizationServer()
 }
 
@@ -57,6 +222,51 @@ type UnimplementedAuthorizationServer struct {
 func (UnimplementedAuthorizationServer) Allow(context.Context, *AllowReq) (*AllowResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Allow not implemented")
 }
+func (UnimplementedAuthorizationServer) Block(context.Context, *BlockReq) (*BlockResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Block not implemented")
+}
+func (UnimplementedAuthorizationServer) Unblock(context.Context, *UnblockReq) (*UnblockResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unblock not implemented")
+}
+func (UnimplementedAuthorizationServer) ListBlock(context.Context, *ListBlockReq) (*ListBlockResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBlock not implemented")
+}
+func (UnimplementedAuthorizationServer) Lock(context.Context, *LockReq) (*LockResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Lock not implemented")
+}
+func (UnimplementedAuthorizationServer) Unlock(context.Context, *UnlockReq) (*UnlockResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unlock not implemented")
+}
+func (UnimplementedAuthorizationServer) SetProfileVisibility(context.Context, *SetProfileVisibilityReq) (*SetProfileVisibilityResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProfileVisibility not implemented")
+}
+func (UnimplementedAuthorizationServer) SetCommunityVisibility(context.Context, *SetCommunityVisibilityReq) (*SetCommunityVisibilityResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCommunityVisibility not implemented")
+}
+func (UnimplementedAuthorizationServer) AddMember(context.Context, *AddMemberReq) (*AddMemberResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddMember not implemented")
+}
+func (UnimplementedAuthorizationServer) RemoveMember(context.Context, *RemoveMemberReq) (*RemoveMemberResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMember not implemented")
+}
+func (UnimplementedAuthorizationServer) Grant(context.Context, *GrantReq) (*GrantResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Grant not implemented")
+}
+func (UnimplementedAuthorizationServer) Revoke(context.Context, *RevokeReq) (*RevokeResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Revoke not implemented")
+}
+func (UnimplementedAuthorizationServer) Report(context.Context, *ReportReq) (*ReportResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Report not implemented")
+}
+func (UnimplementedAuthorizationServer) CloseReport(context.Context, *CloseReportReq) (*CloseReportResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseReport not implemented")
+}
+func (UnimplementedAuthorizationServer) Follow(context.Context, *FollowReq) (*FollowResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Follow not implemented")
+}
+func (UnimplementedAuthorizationServer) Unfollow(context.Context, *UnfollowReq) (*UnfollowResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unfollow not implemented")
+}
 func (UnimplementedAuthorizationServer) mustEmbedUnimplementedAuthorizationServer() {}
 
 // UnsafeAuthorizationServer may be embedded to opt out of forward compatibility for this service.
@@ -88,6 +298,276 @@ func _Authorization_Allow_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Authorization_Block_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServer).Block(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authorization.authorization/block",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServer).Block(ctx, req.(*BlockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authorization_Unblock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnblockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServer).Unblock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authorization.authorization/unblock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServer).Unblock(ctx, req.(*UnblockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authorization_ListBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServer).ListBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authorization.authorization/listBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServer).ListBlock(ctx, req.(*ListBlockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authorization_Lock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServer).Lock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authorization.authorization/lock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServer).Lock(ctx, req.(*LockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authorization_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServer).Unlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authorization.authorization/unlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServer).Unlock(ctx, req.(*UnlockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authorization_SetProfileVisibility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetProfileVisibilityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServer).SetProfileVisibility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authorization.authorization/setProfileVisibility",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServer).SetProfileVisibility(ctx, req.(*SetProfileVisibilityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authorization_SetCommunityVisibility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCommunityVisibilityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServer).SetCommunityVisibility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authorization.authorization/setCommunityVisibility",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServer).SetCommunityVisibility(ctx, req.(*SetCommunityVisibilityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authorization_AddMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddMemberReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServer).AddMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authorization.authorization/addMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServer).AddMember(ctx, req.(*AddMemberReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authorization_RemoveMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveMemberReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServer).RemoveMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authorization.authorization/removeMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServer).RemoveMember(ctx, req.(*RemoveMemberReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authorization_Grant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServer).Grant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authorization.authorization/grant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServer).Grant(ctx, req.(*GrantReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authorization_Revoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServer).Revoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authorization.authorization/revoke",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServer).Revoke(ctx, req.(*RevokeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authorization_Report_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServer).Report(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authorization.authorization/report",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServer).Report(ctx, req.(*ReportReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authorization_CloseReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseReportReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServer).CloseReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authorization.authorization/closeReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServer).CloseReport(ctx, req.(*CloseReportReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authorization_Follow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FollowReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServer).Follow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authorization.authorization/follow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServer).Follow(ctx, req.(*FollowReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authorization_Unfollow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnfollowReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServer).Unfollow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authorization.authorization/unfollow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServer).Unfollow(ctx, req.(*UnfollowReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Authorization_ServiceDesc is the grpc.ServiceDesc for Authorization service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -99,6 +579,66 @@ var Authorization_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "allow",
 			Handler:    _Authorization_Allow_Handler,
 		},
+		{
+			MethodName: "block",
+			Handler:    _Authorization_Block_Handler,
+		},
+		{
+			MethodName: "unblock",
+			Handler:    _Authorization_Unblock_Handler,
+		},
+		{
+			MethodName: "listBlock",
+			Handler:    _Authorization_ListBlock_Handler,
+		},
+		{
+			MethodName: "lock",
+			Handler:    _Authorization_Lock_Handler,
+		},
+		{
+			MethodName: "unlock",
+			Handler:    _Authorization_Unlock_Handler,
+		},
+		{
+			MethodName: "setProfileVisibility",
+			Handler:    _Authorization_SetProfileVisibility_Handler,
+		},
+		{
+			MethodName: "setCommunityVisibility",
+			Handler:    _Authorization_SetCommunityVisibility_Handler,
+		},
+		{
+			MethodName: "addMember",
+			Handler:    _Authorization_AddMember_Handler,
+		},
+		{
+			MethodName: "removeMember",
+			Handler:    _Authorization_RemoveMember_Handler,
+		},
+		{
+			MethodName: "grant",
+			Handler:    _Authorization_Grant_Handler,
+		},
+		{
+			MethodName: "revoke",
+			Handler:    _Authorization_Revoke_Handler,
+		},
+		{
+			MethodName: "report",
+			Handler:    _Authorization_Report_Handler,
+		},
+		{
+			MethodName: "closeReport",
+			Handler:    _Authorization_CloseReport_Handler,
+		},
+		{
+			MethodName: "follow",
+			Handler:    _Authorization_Follow_Handler,
+		},
+		{
+			MethodName: "unfollow",
+			Handler:    _Authorization_Unfollow_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "authorization.proto",